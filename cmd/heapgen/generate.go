@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// config describes generated heaps
+type config struct {
+	Package string
+	Type    string
+	Name    string
+	Less    string
+	Imports []string
+	Factor  int
+}
+
+// typeName returns capitalized type name without pointer and package qualifier
+func typeName(typ string) string {
+	name := strings.TrimLeft(typ, "*")
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	if name == "" {
+		return ""
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func (cfg config) validate() error {
+	if cfg.Package == "" {
+		return errors.New("package name is required")
+	}
+	if cfg.Type == "" {
+		return errors.New("type is required")
+	}
+	if _, err := parser.ParseExpr(cfg.Type); err != nil {
+		return fmt.Errorf("wrong type %q: %w", cfg.Type, err)
+	}
+	if !token.IsIdentifier(cfg.Name) {
+		return fmt.Errorf("wrong name %q", cfg.Name)
+	}
+	if _, err := parser.ParseExpr(cfg.Less); err != nil {
+		return fmt.Errorf("wrong less expression %q: %w", cfg.Less, err)
+	}
+	if cfg.Factor < 2 {
+		return fmt.Errorf("wrong value for factor: %d. Cannot be less than 2", cfg.Factor)
+	}
+	return nil
+}
+
+// spec describes one generated sift function set, heap or priority queue
+type spec struct {
+	Type   string
+	Name   string
+	Prefix string
+	// Kind is Min or Max
+	Kind string
+	// Word is min or max used in doc comments
+	Word string
+	// Sift is Kind of sift functions used by heap or priority queue
+	Sift string
+	// Up, Child, Down and Evict are comparisons inlined into sift functions and priority queue push
+	Up    string
+	Child string
+	Down  string
+	Evict string
+}
+
+// generate returns formatted source of heaps described by cfg
+func generate(cfg config) ([]byte, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	base := spec{
+		Type:   cfg.Type,
+		Name:   cfg.Name,
+		Prefix: string(unicode.ToLower(rune(cfg.Name[0]))) + cfg.Name[1:],
+	}
+	with := func(s spec) spec {
+		s.Type, s.Name, s.Prefix = base.Type, base.Name, base.Prefix
+		return s
+	}
+	data := struct {
+		config
+		Prefix string
+		Sifts  []spec
+		Heaps  []spec
+		PQs    []spec
+	}{
+		config: cfg,
+		Prefix: base.Prefix,
+		Sifts: []spec{
+			with(spec{Kind: "Min", Up: "Less(item, items[parent])", Child: "Less(items[i], items[child])", Down: "Less(items[child], item)"}),
+			with(spec{Kind: "Max", Up: "Less(items[parent], item)", Child: "Less(items[child], items[i])", Down: "Less(item, items[child])"}),
+		},
+		Heaps: []spec{
+			with(spec{Kind: "Min", Word: "min", Sift: "Min"}),
+			with(spec{Kind: "Max", Word: "max", Sift: "Max"}),
+		},
+		PQs: []spec{
+			with(spec{Kind: "Min", Word: "min", Sift: "Max", Evict: "Less(h.items[0], item)"}),
+			with(spec{Kind: "Max", Word: "max", Sift: "Min", Evict: "Less(item, h.items[0])"}),
+		},
+	}
+	var buf bytes.Buffer
+	if err := heapTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format generated code: %w", err)
+	}
+	return src, nil
+}
+
+var heapTemplate = template.Must(template.New("heap").Parse(`// Code generated by heapgen; DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+const {{.Prefix}}Factor = {{.Factor}}
+
+func {{.Prefix}}Less(a, b {{.Type}}) bool {
+	return {{.Less}}
+}
+{{range .Sifts}}{{template "sift" .}}{{end}}
+{{- range .Heaps}}{{template "heaptype" .}}{{end}}
+{{- range .PQs}}{{template "pq" .}}{{end}}`))
+
+func init() {
+	template.Must(heapTemplate.New("sift").Parse(`
+func {{.Prefix}}{{.Kind}}Up(items []{{.Type}}, idx int) {
+	item := items[idx]
+	for idx > 0 {
+		parent := (idx - 1) / {{.Prefix}}Factor
+		if !{{.Prefix}}{{.Up}} {
+			break
+		}
+		items[idx] = items[parent]
+		idx = parent
+	}
+	items[idx] = item
+}
+
+func {{.Prefix}}{{.Kind}}Down(items []{{.Type}}, idx int) {
+	item := items[idx]
+	for {
+		first := idx*{{.Prefix}}Factor + 1
+		if first >= len(items) {
+			break
+		}
+		child := first
+		for i := first + 1; i < first+{{.Prefix}}Factor && i < len(items); i++ {
+			if {{.Prefix}}{{.Child}} {
+				child = i
+			}
+		}
+		if !{{.Prefix}}{{.Down}} {
+			break
+		}
+		items[idx] = items[child]
+		idx = child
+	}
+	items[idx] = item
+}
+
+func {{.Prefix}}{{.Kind}}Heapify(items []{{.Type}}) {
+	if len(items) == 0 {
+		return
+	}
+	for i := (len(items) - 1) / {{.Prefix}}Factor; i >= 0; i-- {
+		{{.Prefix}}{{.Kind}}Down(items, i)
+	}
+}
+
+func {{.Prefix}}{{.Kind}}Pop(items []{{.Type}}) ({{.Type}}, []{{.Type}}) {
+	if len(items) == 0 {
+		panic("empty heap")
+	}
+	last := len(items) - 1
+	item := items[0]
+	items[0] = items[last]
+	var zero {{.Type}}
+	items[last] = zero
+	items = items[:last]
+	if last > 0 {
+		{{.Prefix}}{{.Kind}}Down(items, 0)
+	}
+	return item, items
+}
+`))
+	template.Must(heapTemplate.New("heaptype").Parse(`
+// {{.Name}}{{.Kind}}Heap is heap that returns element with {{.Word}} priority
+type {{.Name}}{{.Kind}}Heap struct {
+	items []{{.Type}}
+}
+
+// Push adds item into heap
+func (h *{{.Name}}{{.Kind}}Heap) Push(item {{.Type}}) {
+	h.items = append(h.items, item)
+	{{.Prefix}}{{.Sift}}Up(h.items, len(h.items)-1)
+}
+
+// Pop returns and deletes {{.Word}} value
+func (h *{{.Name}}{{.Kind}}Heap) Pop() {{.Type}} {
+	var item {{.Type}}
+	item, h.items = {{.Prefix}}{{.Sift}}Pop(h.items)
+	return item
+}
+
+// TryPop returns and deletes {{.Word}} value. Returns false if heap is empty
+func (h *{{.Name}}{{.Kind}}Heap) TryPop() ({{.Type}}, bool) {
+	if len(h.items) == 0 {
+		var item {{.Type}}
+		return item, false
+	}
+	return h.Pop(), true
+}
+
+// Pick returns {{.Word}} value
+func (h *{{.Name}}{{.Kind}}Heap) Pick() {{.Type}} {
+	if len(h.items) == 0 {
+		panic("empty heap")
+	}
+	return h.items[0]
+}
+
+// Empty either heap is blank
+func (h *{{.Name}}{{.Kind}}Heap) Empty() bool {
+	return len(h.items) == 0
+}
+
+// Size returns heap size
+func (h *{{.Name}}{{.Kind}}Heap) Size() int {
+	return len(h.items)
+}
+
+// Heapify initializes heap
+func (h *{{.Name}}{{.Kind}}Heap) Heapify(items ...{{.Type}}) {
+	h.items = items
+	{{.Prefix}}{{.Sift}}Heapify(h.items)
+}
+
+// Slice returns heap slice
+func (h *{{.Name}}{{.Kind}}Heap) Slice() []{{.Type}} {
+	res := make([]{{.Type}}, 0, len(h.items))
+	for len(h.items) > 0 {
+		res = append(res, h.Pop())
+	}
+	return res
+}
+`))
+	template.Must(heapTemplate.New("pq").Parse(`
+// {{.Name}}{{.Kind}}PQ is {{.Word}}imum bounded priority queue.
+// Zero value is empty unbounded priority queue
+type {{.Name}}{{.Kind}}PQ struct {
+	items []{{.Type}}
+	size  int
+}
+
+// New{{.Name}}{{.Kind}}PQ creates {{.Word}}imum priority queue of size
+func New{{.Name}}{{.Kind}}PQ(size int) {{.Name}}{{.Kind}}PQ {
+	return {{.Name}}{{.Kind}}PQ{size: size}
+}
+
+// Push adds item into priority queue
+func (h *{{.Name}}{{.Kind}}PQ) Push(item {{.Type}}) {
+	if h.size < 1 || len(h.items) < h.size {
+		h.items = append(h.items, item)
+		{{.Prefix}}{{.Sift}}Up(h.items, len(h.items)-1)
+		return
+	}
+	if {{.Prefix}}{{.Evict}} {
+		return
+	}
+	h.items[0] = item
+	{{.Prefix}}{{.Sift}}Down(h.items, 0)
+}
+
+// Pop returns and deletes {{if eq .Kind "Min"}}max{{else}}min{{end}} value
+func (h *{{.Name}}{{.Kind}}PQ) Pop() {{.Type}} {
+	var item {{.Type}}
+	item, h.items = {{.Prefix}}{{.Sift}}Pop(h.items)
+	return item
+}
+
+// TryPop returns and deletes {{if eq .Kind "Min"}}max{{else}}min{{end}} value. Returns false if priority queue is empty
+func (h *{{.Name}}{{.Kind}}PQ) TryPop() ({{.Type}}, bool) {
+	if len(h.items) == 0 {
+		var item {{.Type}}
+		return item, false
+	}
+	return h.Pop(), true
+}
+
+// Pick returns {{if eq .Kind "Min"}}max{{else}}min{{end}} value
+func (h *{{.Name}}{{.Kind}}PQ) Pick() {{.Type}} {
+	if len(h.items) == 0 {
+		panic("empty priority queue")
+	}
+	return h.items[0]
+}
+
+// Empty either priority queue is blank
+func (h *{{.Name}}{{.Kind}}PQ) Empty() bool {
+	return len(h.items) == 0
+}
+
+// Size returns priority queue size
+func (h *{{.Name}}{{.Kind}}PQ) Size() int {
+	return len(h.items)
+}
+
+// Heapify initializes priority queue
+func (h *{{.Name}}{{.Kind}}PQ) Heapify(items ...{{.Type}}) {
+	size := len(items)
+	if h.size > 0 {
+		size = min(size, h.size)
+	}
+	h.items = items[:size]
+	{{.Prefix}}{{.Sift}}Heapify(h.items)
+	for _, item := range items[size:] {
+		h.Push(item)
+	}
+}
+
+// Slice returns priority queue slice
+func (h *{{.Name}}{{.Kind}}PQ) Slice() []{{.Type}} {
+	res := make([]{{.Type}}, 0, len(h.items))
+	for len(h.items) > 0 {
+		res = append(res, h.Pop())
+	}
+	return res
+}
+
+// OrderedSlice returns priority queue slice ordered by priority
+func (h *{{.Name}}{{.Kind}}PQ) OrderedSlice() []{{.Type}} {
+	res := make([]{{.Type}}, len(h.items))
+	for i := len(h.items) - 1; i >= 0; i-- {
+		res[i] = h.Pop()
+	}
+	return res
+}
+`))
+}
@@ -0,0 +1,48 @@
+// Command heapgen generates type specialized min, max heaps and min, max priority queues.
+// Comparisons are inlined instead of being called through function values, e.g.
+//
+//	//go:generate go run github.com/trezorg/heap/cmd/heapgen -type Task -less "a.Priority < b.Priority"
+//
+// generates TaskMinHeap, TaskMaxHeap, TaskMinPQ and TaskMaxPQ types into task_heap.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("heapgen: ")
+
+	var cfg config
+	var imports, output string
+	flag.StringVar(&cfg.Type, "type", "", "element type, required")
+	flag.StringVar(&cfg.Less, "less", "a < b", "expression reporting whether element a has less priority value than element b")
+	flag.StringVar(&cfg.Name, "name", "", "prefix of generated types, defaults to capitalized type name")
+	flag.StringVar(&cfg.Package, "package", os.Getenv("GOPACKAGE"), "package name, defaults to $GOPACKAGE")
+	flag.IntVar(&cfg.Factor, "factor", 2, "heap factor")
+	flag.StringVar(&imports, "imports", "", "comma separated import paths used by type or expression")
+	flag.StringVar(&output, "output", "", "output file, defaults to <name>_heap.go")
+	flag.Parse()
+
+	if imports != "" {
+		cfg.Imports = strings.Split(imports, ",")
+	}
+	if cfg.Name == "" {
+		cfg.Name = typeName(cfg.Type)
+	}
+	src, err := generate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if output == "" {
+		output = fmt.Sprintf("%s_heap.go", strings.ToLower(cfg.Name))
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeName(t *testing.T) {
+	require.Equal(t, "Int", typeName("int"))
+	require.Equal(t, "Task", typeName("*Task"))
+	require.Equal(t, "Duration", typeName("time.Duration"))
+	require.Equal(t, "", typeName(""))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	valid := config{Package: "p", Type: "int", Name: "Int", Less: "a < b", Factor: 2}
+	_, err := generate(valid)
+	require.NoError(t, err)
+
+	for _, change := range []func(*config){
+		func(cfg *config) { cfg.Package = "" },
+		func(cfg *config) { cfg.Type = "" },
+		func(cfg *config) { cfg.Name = "1x" },
+		func(cfg *config) { cfg.Less = "a <" },
+		func(cfg *config) { cfg.Factor = 1 },
+	} {
+		cfg := valid
+		change(&cfg)
+		_, err := generate(cfg)
+		require.Error(t, err)
+	}
+}
+
+func TestGenerateTypeChecks(t *testing.T) {
+	src, err := generate(config{
+		Package: "p",
+		Type:    "*Task",
+		Name:    "Task",
+		Less:    "a.Priority > b.Priority",
+		Factor:  4,
+	})
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	generated, err := parser.ParseFile(fset, "task_heap.go", src, 0)
+	require.NoError(t, err)
+	task, err := parser.ParseFile(fset, "task.go", "package p\ntype Task struct{ Priority float64 }\n", 0)
+	require.NoError(t, err)
+
+	conf := types.Config{}
+	pkg, err := conf.Check("p", fset, []*ast.File{generated, task}, nil)
+	require.NoError(t, err)
+	for _, name := range []string{"TaskMinHeap", "TaskMaxHeap", "TaskMinPQ", "TaskMaxPQ", "NewTaskMinPQ", "NewTaskMaxPQ"} {
+		require.NotNil(t, pkg.Scope().Lookup(name), name)
+	}
+}
+
+const generatedTest = `package p
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestGenerated(t *testing.T) {
+	items := rand.Perm(1000)
+	var minH IntMinHeap
+	var maxH IntMaxHeap
+	for _, item := range items {
+		minH.Push(item)
+		maxH.Push(item)
+	}
+	minPQ, maxPQ := NewIntMinPQ(10), NewIntMaxPQ(10)
+	minPQ.Heapify(append([]int(nil), items...)...)
+	maxPQ.Heapify(append([]int(nil), items...)...)
+
+	sort.Ints(items)
+	check := func(name string, expected, actual []int) {
+		for i := range expected {
+			if expected[i] != actual[i] {
+				t.Fatalf("%s: wrong item %d at %d, expected %d", name, actual[i], i, expected[i])
+			}
+		}
+	}
+	check("min heap", items, minH.Slice())
+	check("min pq", items[:10], minPQ.OrderedSlice())
+	check("max pq", []int{999, 998, 997, 996, 995, 994, 993, 992, 991, 990}, maxPQ.OrderedSlice())
+	sort.Sort(sort.Reverse(sort.IntSlice(items)))
+	check("max heap", items, maxH.Slice())
+}
+`
+
+func TestGeneratedHeapsRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	for _, factor := range []int{2, 3} {
+		src, err := generate(config{Package: "p", Type: "int", Name: "Int", Less: "a < b", Factor: factor})
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module p\n\ngo 1.22\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "int_heap.go"), src, 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "int_heap_test.go"), []byte(generatedTest), 0o644))
+
+		cmd := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "test", "./...")
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+}
@@ -0,0 +1,190 @@
+package comparable
+
+import "math/bits"
+
+// pushMany adds items into heap. Heap is rebuilt at once when it is cheaper
+// than sifting up every item, i.e. when batch is large relative to heap
+func (h *baseHeap[T]) pushMany(items []T) {
+	n := h.len() + len(items)
+	if len(items)*bits.Len(uint(n)) > n {
+		h.save()
+		h.extend(items)
+		h.rebuild()
+		return
+	}
+	for _, item := range items {
+		h.push(item)
+	}
+}
+
+// pushManyBounded adds items into priority queue of size. Items filling free room are added at once,
+// the rest are pushed one by one evicting items with the worst priority
+func (h *baseHeap[T]) pushManyBounded(size int, items []T) {
+	free := len(items)
+	if size > 0 {
+		free = min(max(size-h.len(), 0), len(items))
+	}
+	h.pushMany(items[:free])
+	for _, item := range items[free:] {
+		h.pushBounded(size, item)
+	}
+}
+
+// popN returns and deletes at most n items in pop order
+func (h *baseHeap[T]) popN(n int) []T {
+	res := make([]T, min(max(n, 0), h.len()))
+	for i := range res {
+		res[i] = h.pop()
+	}
+	return res
+}
+
+// pushPop adds item and then returns and deletes the top item with one sift down
+func (h *baseHeap[T]) pushPop(item T) T {
+	if h.empty() || !h.check(h.at(0), item) {
+		return item
+	}
+	top := h.at(0)
+	h.set(0, item)
+	h.down(0)
+	return top
+}
+
+// replace returns and deletes the top item and then adds item with one sift down
+func (h *baseHeap[T]) replace(item T) T {
+	if h.empty() {
+		panic(ErrEmpty)
+	}
+	top := h.at(0)
+	h.set(0, item)
+	h.down(0)
+	return top
+}
+
+// PushMany adds items into heap. Large batch is added with one heapify pass instead of pushing items one by one
+func (h *MinHeap[T]) PushMany(items ...T) {
+	h.base().pushMany(items)
+}
+
+// PushMany adds items into heap. Large batch is added with one heapify pass instead of pushing items one by one
+func (h *MaxHeap[T]) PushMany(items ...T) {
+	h.base().pushMany(items)
+}
+
+// PushMany adds items into priority queue. Items filling free room are added with one heapify pass
+// for large batch, the rest are pushed one by one evicting items
+func (h *MinPQ[T]) PushMany(items ...T) {
+	h.base().pushManyBounded(h.size, items)
+}
+
+// PushMany adds items into priority queue. Items filling free room are added with one heapify pass
+// for large batch, the rest are pushed one by one evicting items
+func (h *MaxPQ[T]) PushMany(items ...T) {
+	h.base().pushManyBounded(h.size, items)
+}
+
+// PushMany adds items into heap. Large batch is added with one heapify pass instead of pushing items one by one
+func (h *CompareMinHeap[T]) PushMany(items ...T) {
+	h.base().pushMany(items)
+}
+
+// PushMany adds items into heap. Large batch is added with one heapify pass instead of pushing items one by one
+func (h *CompareMaxHeap[T]) PushMany(items ...T) {
+	h.base().pushMany(items)
+}
+
+// PushMany adds items into priority queue. Items filling free room are added with one heapify pass
+// for large batch, the rest are pushed one by one evicting items
+func (h *CompareMinPQ[T]) PushMany(items ...T) {
+	h.base().pushManyBounded(h.size, items)
+}
+
+// PushMany adds items into priority queue. Items filling free room are added with one heapify pass
+// for large batch, the rest are pushed one by one evicting items
+func (h *CompareMaxPQ[T]) PushMany(items ...T) {
+	h.base().pushManyBounded(h.size, items)
+}
+
+// PopN returns and deletes at most n min values in ascending order
+func (h *MinHeap[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PopN returns and deletes at most n max values in descending order
+func (h *MaxHeap[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PopN returns and deletes at most n items in Pop order
+func (h *MinPQ[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PopN returns and deletes at most n items in Pop order
+func (h *MaxPQ[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PopN returns and deletes at most n min values in ascending order
+func (h *CompareMinHeap[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PopN returns and deletes at most n max values in descending order
+func (h *CompareMaxHeap[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PopN returns and deletes at most n items in Pop order
+func (h *CompareMinPQ[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PopN returns and deletes at most n items in Pop order
+func (h *CompareMaxPQ[T]) PopN(n int) []T {
+	return h.base().popN(n)
+}
+
+// PushPop adds item and then returns and deletes min value. It is faster than Push followed by Pop
+func (h *MinHeap[T]) PushPop(item T) T {
+	return h.base().pushPop(item)
+}
+
+// PushPop adds item and then returns and deletes max value. It is faster than Push followed by Pop
+func (h *MaxHeap[T]) PushPop(item T) T {
+	return h.base().pushPop(item)
+}
+
+// PushPop adds item and then returns and deletes min value. It is faster than Push followed by Pop
+func (h *CompareMinHeap[T]) PushPop(item T) T {
+	return h.base().pushPop(item)
+}
+
+// PushPop adds item and then returns and deletes max value. It is faster than Push followed by Pop
+func (h *CompareMaxHeap[T]) PushPop(item T) T {
+	return h.base().pushPop(item)
+}
+
+// Replace returns and deletes min value and then adds item. It is faster than Pop followed by Push.
+// Panics if heap is empty
+func (h *MinHeap[T]) Replace(item T) T {
+	return h.base().replace(item)
+}
+
+// Replace returns and deletes max value and then adds item. It is faster than Pop followed by Push.
+// Panics if heap is empty
+func (h *MaxHeap[T]) Replace(item T) T {
+	return h.base().replace(item)
+}
+
+// Replace returns and deletes min value and then adds item. It is faster than Pop followed by Push.
+// Panics if heap is empty
+func (h *CompareMinHeap[T]) Replace(item T) T {
+	return h.base().replace(item)
+}
+
+// Replace returns and deletes max value and then adds item. It is faster than Pop followed by Push.
+// Panics if heap is empty
+func (h *CompareMaxHeap[T]) Replace(item T) T {
+	return h.base().replace(item)
+}
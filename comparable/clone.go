@@ -0,0 +1,200 @@
+package comparable
+
+import (
+	"maps"
+	"slices"
+)
+
+// cloneItems returns copy of items. Items are copied with copyItem if it is not nil
+func cloneItems[T any](items []T, copyItem func(item T) T) []T {
+	res := slices.Clone(items)
+	if copyItem != nil {
+		for i, item := range res {
+			res[i] = copyItem(item)
+		}
+	}
+	return res
+}
+
+// clone returns copy of heap with own items. Items kept in storage are copied into slice
+func (h *baseHeap[T]) clone(copyItem func(item T) T) baseHeap[T] {
+	c := *h
+	if h.storage != nil {
+		c.storage, c.items = nil, h.snapshot()
+	}
+	c.items = cloneItems(c.items, copyItem)
+	c.undo = nil
+	return c
+}
+
+// clone returns copy of priority queue with own items
+func (h *baseWeightedPQ[T]) clone(copyItem func(item T) T) baseWeightedPQ[T] {
+	c := *h
+	c.baseHeap = h.baseHeap.clone(copyItem)
+	return c
+}
+
+// clone returns copy of heap with own items, keys and index. copyItem should keep item key
+func (h *baseUniqueHeap[T, K]) clone(copyItem func(item T) T) baseUniqueHeap[T, K] {
+	c := *h
+	c.baseHeap = h.baseHeap.clone(copyItem)
+	c.keys = slices.Clone(h.keys)
+	c.index = maps.Clone(h.index)
+	return c
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MinHeap[T]) Clone() MinHeap[T] {
+	return MinHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MaxHeap[T]) Clone() MaxHeap[T] {
+	return MaxHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MinPQ[T]) Clone() MinPQ[T] {
+	return MinPQ[T]{baseHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MaxPQ[T]) Clone() MaxPQ[T] {
+	return MaxPQ[T]{baseHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *CompareMinHeap[T]) Clone() CompareMinHeap[T] {
+	return CompareMinHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *CompareMaxHeap[T]) Clone() CompareMaxHeap[T] {
+	return CompareMaxHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *CompareMinPQ[T]) Clone() CompareMinPQ[T] {
+	return CompareMinPQ[T]{baseHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *CompareMaxPQ[T]) Clone() CompareMaxPQ[T] {
+	return CompareMaxPQ[T]{baseHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *WeightedMinPQ[T]) Clone() WeightedMinPQ[T] {
+	return WeightedMinPQ[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *WeightedMaxPQ[T]) Clone() WeightedMaxPQ[T] {
+	return WeightedMaxPQ[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *UniqueMinHeap[T, K]) Clone() UniqueMinHeap[T, K] {
+	return UniqueMinHeap[T, K]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *UniqueMaxHeap[T, K]) Clone() UniqueMaxHeap[T, K] {
+	return UniqueMaxHeap[T, K]{h.clone(nil)}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *UniqueMinPQ[T, K]) Clone() UniqueMinPQ[T, K] {
+	return UniqueMinPQ[T, K]{baseUniqueHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *UniqueMaxPQ[T, K]) Clone() UniqueMaxPQ[T, K] {
+	return UniqueMaxPQ[T, K]{baseUniqueHeap: h.clone(nil), size: h.size}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MinHeap[T]) CloneFunc(copyItem func(item T) T) MinHeap[T] {
+	return MinHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MaxHeap[T]) CloneFunc(copyItem func(item T) T) MaxHeap[T] {
+	return MaxHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MinPQ[T]) CloneFunc(copyItem func(item T) T) MinPQ[T] {
+	return MinPQ[T]{baseHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MaxPQ[T]) CloneFunc(copyItem func(item T) T) MaxPQ[T] {
+	return MaxPQ[T]{baseHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *CompareMinHeap[T]) CloneFunc(copyItem func(item T) T) CompareMinHeap[T] {
+	return CompareMinHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *CompareMaxHeap[T]) CloneFunc(copyItem func(item T) T) CompareMaxHeap[T] {
+	return CompareMaxHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *CompareMinPQ[T]) CloneFunc(copyItem func(item T) T) CompareMinPQ[T] {
+	return CompareMinPQ[T]{baseHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *CompareMaxPQ[T]) CloneFunc(copyItem func(item T) T) CompareMaxPQ[T] {
+	return CompareMaxPQ[T]{baseHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *WeightedMinPQ[T]) CloneFunc(copyItem func(item T) T) WeightedMinPQ[T] {
+	return WeightedMinPQ[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *WeightedMaxPQ[T]) CloneFunc(copyItem func(item T) T) WeightedMaxPQ[T] {
+	return WeightedMaxPQ[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *UniqueMinHeap[T, K]) CloneFunc(copyItem func(item T) T) UniqueMinHeap[T, K] {
+	return UniqueMinHeap[T, K]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *UniqueMaxHeap[T, K]) CloneFunc(copyItem func(item T) T) UniqueMaxHeap[T, K] {
+	return UniqueMaxHeap[T, K]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *UniqueMinPQ[T, K]) CloneFunc(copyItem func(item T) T) UniqueMinPQ[T, K] {
+	return UniqueMinPQ[T, K]{baseUniqueHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *UniqueMaxPQ[T, K]) CloneFunc(copyItem func(item T) T) UniqueMaxPQ[T, K] {
+	return UniqueMaxPQ[T, K]{baseUniqueHeap: h.clone(copyItem), size: h.size}
+}
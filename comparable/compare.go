@@ -0,0 +1,502 @@
+package comparable
+
+// Comparer is three way comparison interface following cmp.Compare convention.
+// Compare returns negative number if item is less than other, zero if they are equal and positive number otherwise
+type Comparer[T any] interface {
+	Compare(T) int
+}
+
+// ByCompare adapts Comparer to Comparator, Less makes one Compare call
+type ByCompare[T Comparer[T]] struct {
+	Value T
+}
+
+// Less reports whether item is less than other
+func (item ByCompare[T]) Less(other ByCompare[T]) bool {
+	return item.Value.Compare(other.Value) < 0
+}
+
+// Compare compares item with other
+func (item ByCompare[T]) Compare(other ByCompare[T]) int {
+	return item.Value.Compare(other.Value)
+}
+
+// ByLess adapts Comparator to Comparer, Compare makes at most two Less calls
+type ByLess[T Comparator[T]] struct {
+	Value T
+}
+
+// Less reports whether item is less than other
+func (item ByLess[T]) Less(other ByLess[T]) bool {
+	return item.Value.Less(other.Value)
+}
+
+// Compare compares item with other
+func (item ByLess[T]) Compare(other ByLess[T]) int {
+	return Compare(item.Value, other.Value)
+}
+
+// Compare compares Comparator items a and b the way cmp.Compare does
+func Compare[T Comparator[T]](a, b T) int {
+	switch {
+	case a.Less(b):
+		return -1
+	case b.Less(a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Less reports whether Comparer item a is less than b
+func Less[T Comparer[T]](a, b T) bool {
+	return a.Compare(b) < 0
+}
+
+// CompareMinHeap is heap of Comparer items that returns element with min priority.
+// Zero value is empty heap with factor 2
+type CompareMinHeap[T Comparer[T]] struct {
+	baseHeap[T]
+}
+
+// CompareMaxHeap is heap of Comparer items that returns element with max priority.
+// Zero value is empty heap with factor 2
+type CompareMaxHeap[T Comparer[T]] struct {
+	baseHeap[T]
+}
+
+// CompareMinPQ is minimum bounded priority queue of Comparer items.
+// Zero value is empty unbounded priority queue
+type CompareMinPQ[T Comparer[T]] struct {
+	baseHeap[T]
+	size int
+}
+
+// CompareMaxPQ is maximum bounded priority queue of Comparer items.
+// Zero value is empty unbounded priority queue
+type CompareMaxPQ[T Comparer[T]] struct {
+	baseHeap[T]
+	size int
+}
+
+func compareMinCheck[T Comparer[T]](item1 T, item2 T) bool {
+	return item1.Compare(item2) < 0
+}
+
+func compareMaxCheck[T Comparer[T]](item1 T, item2 T) bool {
+	return item1.Compare(item2) > 0
+}
+
+// NewCompareMinHeap creates min heap of Comparer items
+func NewCompareMinHeap[T Comparer[T]](factor int, opts ...Option) (CompareMinHeap[T], error) {
+	baseHeap, err := newHeap(factor, compareMinCheck[T], false, opts)
+	if err != nil {
+		return CompareMinHeap[T]{}, err
+	}
+	return CompareMinHeap[T]{baseHeap}, nil
+}
+
+// NewCompareMaxHeap creates max heap of Comparer items
+func NewCompareMaxHeap[T Comparer[T]](factor int, opts ...Option) (CompareMaxHeap[T], error) {
+	baseHeap, err := newHeap(factor, compareMaxCheck[T], false, opts)
+	if err != nil {
+		return CompareMaxHeap[T]{}, err
+	}
+	return CompareMaxHeap[T]{baseHeap}, nil
+}
+
+// NewCompareMinPQ creates minimum priority queue of Comparer items with heap factor 2.
+// Size should be positive or Unbounded
+func NewCompareMinPQ[T Comparer[T]](size int, opts ...Option) (CompareMinPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return CompareMinPQ[T]{}, err
+	}
+	baseHeap, err := newHeap(2, compareMaxCheck[T], true, opts)
+	if err != nil {
+		return CompareMinPQ[T]{}, err
+	}
+	return CompareMinPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+// NewCompareMaxPQ creates maximum priority queue of Comparer items with heap factor 2.
+// Size should be positive or Unbounded
+func NewCompareMaxPQ[T Comparer[T]](size int, opts ...Option) (CompareMaxPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return CompareMaxPQ[T]{}, err
+	}
+	baseHeap, err := newHeap(2, compareMinCheck[T], true, opts)
+	if err != nil {
+		return CompareMaxPQ[T]{}, err
+	}
+	return CompareMaxPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+func (h *CompareMinHeap[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(compareMinCheck[T])
+	}
+	return &h.baseHeap
+}
+
+func (h *CompareMaxHeap[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(compareMaxCheck[T])
+	}
+	return &h.baseHeap
+}
+
+func (h *CompareMinPQ[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(compareMaxCheck[T])
+	}
+	return &h.baseHeap
+}
+
+func (h *CompareMaxPQ[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(compareMinCheck[T])
+	}
+	return &h.baseHeap
+}
+
+// Push adds item into heap
+func (h *CompareMinHeap[T]) Push(item T) {
+	h.base().push(item)
+}
+
+// Push adds item into heap
+func (h *CompareMaxHeap[T]) Push(item T) {
+	h.base().push(item)
+}
+
+// Push adds item into priority queue
+func (h *CompareMinPQ[T]) Push(item T) {
+	h.base().pushBounded(h.size, item)
+}
+
+// Push adds item into priority queue
+func (h *CompareMaxPQ[T]) Push(item T) {
+	h.base().pushBounded(h.size, item)
+}
+
+// Pop returns and deletes min value
+func (h *CompareMinHeap[T]) Pop() T {
+	return h.base().pop()
+}
+
+// Pop returns and deletes max value
+func (h *CompareMaxHeap[T]) Pop() T {
+	return h.base().pop()
+}
+
+// Pop returns and deletes min value
+func (h *CompareMinPQ[T]) Pop() T {
+	return h.base().pop()
+}
+
+// Pop returns and deletes max value
+func (h *CompareMaxPQ[T]) Pop() T {
+	return h.base().pop()
+}
+
+// TryPop returns and deletes min value. Returns false if heap is empty
+func (h *CompareMinHeap[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes max value. Returns false if heap is empty
+func (h *CompareMaxHeap[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes min value. Returns false if priority queue is empty
+func (h *CompareMinPQ[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes max value. Returns false if priority queue is empty
+func (h *CompareMaxPQ[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// Pick returns min value
+func (h *CompareMinHeap[T]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns max value
+func (h *CompareMaxHeap[T]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns min value
+func (h *CompareMinPQ[T]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns max value
+func (h *CompareMaxPQ[T]) Pick() T {
+	return h.pick()
+}
+
+// TryPick returns min value. Returns false if heap is empty
+func (h *CompareMinHeap[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns max value. Returns false if heap is empty
+func (h *CompareMaxHeap[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns min value. Returns false if priority queue is empty
+func (h *CompareMinPQ[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns max value. Returns false if priority queue is empty
+func (h *CompareMaxPQ[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// Empty either heap is blank
+func (h *CompareMinHeap[T]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either heap is blank
+func (h *CompareMaxHeap[T]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either priority queue is blank
+func (h *CompareMinPQ[T]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either priority queue is blank
+func (h *CompareMaxPQ[T]) Empty() bool {
+	return h.empty()
+}
+
+// Size returns heap size
+func (h *CompareMinHeap[T]) Size() int {
+	return h.len()
+}
+
+// Size returns heap size
+func (h *CompareMaxHeap[T]) Size() int {
+	return h.len()
+}
+
+// Size returns priority queue size
+func (h *CompareMinPQ[T]) Size() int {
+	return h.len()
+}
+
+// Size returns priority queue size
+func (h *CompareMaxPQ[T]) Size() int {
+	return h.len()
+}
+
+// Heapify initializes heap
+func (h *CompareMinHeap[T]) Heapify(items ...T) {
+	h.base().heapify(items...)
+}
+
+// Heapify initializes heap
+func (h *CompareMaxHeap[T]) Heapify(items ...T) {
+	h.base().heapify(items...)
+}
+
+// Heapify initializes priority queue
+func (h *CompareMinPQ[T]) Heapify(items ...T) {
+	size := bound(h.size, len(items))
+	h.base().heapify(items[:size]...)
+	for _, item := range items[size:] {
+		h.pushBounded(h.size, item)
+	}
+}
+
+// Heapify initializes priority queue
+func (h *CompareMaxPQ[T]) Heapify(items ...T) {
+	size := bound(h.size, len(items))
+	h.base().heapify(items[:size]...)
+	for _, item := range items[size:] {
+		h.pushBounded(h.size, item)
+	}
+}
+
+// Slice returns heap slice
+func (h *CompareMinHeap[T]) Slice() []T {
+	return h.base().slice()
+}
+
+// Slice returns heap slice
+func (h *CompareMaxHeap[T]) Slice() []T {
+	return h.base().slice()
+}
+
+// Slice returns priority queue slice
+func (h *CompareMinPQ[T]) Slice() []T {
+	return h.base().slice()
+}
+
+// Slice returns priority queue slice
+func (h *CompareMaxPQ[T]) Slice() []T {
+	return h.base().slice()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *CompareMinHeap[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *CompareMaxHeap[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *CompareMinPQ[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *CompareMaxPQ[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Height returns number of heap levels
+func (h *CompareMinHeap[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *CompareMaxHeap[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *CompareMinPQ[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *CompareMaxPQ[T]) Height() int {
+	return h.height()
+}
+
+// Factor returns heap factor
+func (h *CompareMinHeap[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns heap factor
+func (h *CompareMaxHeap[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns priority queue factor
+func (h *CompareMinPQ[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns priority queue factor
+func (h *CompareMaxPQ[T]) Factor() int {
+	return h.base().factor
+}
+
+// Levels returns items grouped by heap levels starting from the min value
+func (h *CompareMinHeap[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by heap levels starting from the max value
+func (h *CompareMaxHeap[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the max value evicted first
+func (h *CompareMinPQ[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the min value evicted first
+func (h *CompareMaxPQ[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// PushEvict adds item into priority queue. When priority queue is full, returns evicted item,
+// which is either item itself or the current max value
+func (h *CompareMinPQ[T]) PushEvict(item T) (T, bool) {
+	return h.base().pushBounded(h.size, item)
+}
+
+// OnEvict sets callback receiving items evicted from priority queue on Push, PushEvict and Heapify.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *CompareMinPQ[T]) OnEvict(evict func(item T)) {
+	h.base().evict = evict
+}
+
+// PushEvict adds item into priority queue. When priority queue is full, returns evicted item,
+// which is either item itself or the current min value
+func (h *CompareMaxPQ[T]) PushEvict(item T) (T, bool) {
+	return h.base().pushBounded(h.size, item)
+}
+
+// OnEvict sets callback receiving items evicted from priority queue on Push, PushEvict and Heapify.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *CompareMaxPQ[T]) OnEvict(evict func(item T)) {
+	h.base().evict = evict
+}
+
+// OrderedSlice return ordered slice from PQ
+func (h *CompareMinPQ[T]) OrderedSlice() []T {
+	return h.base().orderedSlicePQ()
+}
+
+// OrderedSlice return ordered slice from PQ
+func (h *CompareMaxPQ[T]) OrderedSlice() []T {
+	return h.base().orderedSlicePQ()
+}
+
+// Capacity returns priority queue size bound or Unbounded
+func (h *CompareMinPQ[T]) Capacity() int {
+	if h.size < 1 {
+		return Unbounded
+	}
+	return h.size
+}
+
+// Capacity returns priority queue size bound or Unbounded
+func (h *CompareMaxPQ[T]) Capacity() int {
+	if h.size < 1 {
+		return Unbounded
+	}
+	return h.size
+}
+
+// SetCapacity changes priority queue size bound. Shrinking evicts items starting from the max value.
+// Evicted items are passed to eviction callback and returned in eviction order. Size should be positive or Unbounded
+func (h *CompareMinPQ[T]) SetCapacity(size int) ([]T, error) {
+	if err := checkSize(size); err != nil {
+		return nil, err
+	}
+	h.size = size
+	return h.base().shrinkTo(size), nil
+}
+
+// SetCapacity changes priority queue size bound. Shrinking evicts items starting from the min value.
+// Evicted items are passed to eviction callback and returned in eviction order. Size should be positive or Unbounded
+func (h *CompareMaxPQ[T]) SetCapacity(size int) ([]T, error) {
+	if err := checkSize(size); err != nil {
+		return nil, err
+	}
+	h.size = size
+	return h.base().shrinkTo(size), nil
+}
@@ -0,0 +1,148 @@
+package comparable
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trezorg/heap"
+)
+
+type version struct {
+	major, minor int
+}
+
+func (v version) Compare(other version) int {
+	if c := cmp.Compare(v.major, other.major); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.minor, other.minor)
+}
+
+func TestCompareHeaps(t *testing.T) {
+	items := []version{{1, 2}, {0, 9}, {2, 0}, {1, 10}, {1, 2}}
+
+	minH, err := NewCompareMinHeap[version](3)
+	require.NoError(t, err)
+	minH.Heapify(append([]version(nil), items...)...)
+	require.NoError(t, minH.Validate())
+	require.Equal(t, 3, minH.Factor())
+	require.Equal(t, []version{{0, 9}, {1, 2}, {1, 2}, {1, 10}, {2, 0}}, minH.Slice())
+
+	maxH, _ := NewCompareMaxHeap[version](2)
+	for _, item := range items {
+		maxH.Push(item)
+	}
+	require.Equal(t, version{2, 0}, maxH.Pick())
+	require.Equal(t, []version{{2, 0}, {1, 10}, {1, 2}, {1, 2}, {0, 9}}, maxH.Slice())
+
+	minPQ, _ := NewCompareMinPQ[version](2)
+	maxPQ, _ := NewCompareMaxPQ[version](2)
+	minPQ.Heapify(append([]version(nil), items...)...)
+	maxPQ.Heapify(append([]version(nil), items...)...)
+	evicted, ok := maxPQ.PushEvict(version{3, 0})
+	require.True(t, ok)
+	require.Equal(t, version{1, 10}, evicted)
+	require.Equal(t, []version{{0, 9}, {1, 2}}, minPQ.OrderedSlice())
+	require.Equal(t, []version{{3, 0}, {2, 0}}, maxPQ.OrderedSlice())
+
+	var zero CompareMinPQ[version]
+	zero.Heapify(items...)
+	require.Equal(t, Unbounded, zero.Capacity())
+	evictedItems, err := zero.SetCapacity(1)
+	require.NoError(t, err)
+	require.Equal(t, []version{{2, 0}, {1, 10}, {1, 2}, {1, 2}}, evictedItems)
+	require.Equal(t, version{0, 9}, zero.Pop())
+	_, ok = zero.TryPop()
+	require.False(t, ok)
+}
+
+func TestCompareHeapsMethodSet(t *testing.T) {
+	var h CompareMinHeap[version]
+	h.Reserve(8)
+	require.GreaterOrEqual(t, h.Cap(), 8)
+	h.PushMany(version{1, 2}, version{0, 1}, version{3, 0})
+	require.Equal(t, version{0, 1}, h.PushPop(version{2, 0}))
+	require.Equal(t, version{1, 2}, h.Replace(version{0, 5}))
+	id := h.Checkpoint()
+	require.Equal(t, []version{{0, 5}, {2, 0}}, h.PopN(2))
+	require.NoError(t, h.Rollback(id))
+	require.Equal(t, 3, h.Size())
+	c, err := h.Container()
+	require.NoError(t, err)
+	require.Equal(t, 3, c.Len())
+	h.Clear()
+	h.Shrink()
+	require.True(t, h.Empty())
+
+	storage := &heap.SliceStorage[version]{}
+	storage.Append(version{2, 0})
+	storage.Append(version{1, 0})
+	pq, err := NewStorageCompareMaxPQ(Unbounded, storage)
+	require.NoError(t, err)
+	require.Equal(t, heap.Storage[version](storage), pq.Storage())
+	id = pq.Checkpoint()
+	_, err = pq.SetCapacity(1)
+	require.NoError(t, err)
+	require.NoError(t, pq.Commit(id))
+	require.Equal(t, []version{{2, 0}}, pq.OrderedSlice())
+	_, err = NewStorageCompareMinHeap[version](1, nil)
+	require.Error(t, err)
+}
+
+func TestCompareAdapters(t *testing.T) {
+	require.Equal(t, -1, Compare(Item(1), Item(2)))
+	require.Equal(t, 0, Compare(Item(2), Item(2)))
+	require.Equal(t, 1, Compare(Item(3), Item(2)))
+	require.True(t, Less(version{1, 0}, version{1, 1}))
+	require.False(t, Less(version{1, 1}, version{1, 1}))
+
+	require.Equal(t, 1, ByLess[Item]{3}.Compare(ByLess[Item]{2}))
+	require.True(t, ByLess[Item]{1}.Less(ByLess[Item]{2}))
+	require.Equal(t, 0, ByCompare[version]{version{1, 1}}.Compare(ByCompare[version]{version{1, 1}}))
+
+	var _ Comparer[ByLess[Item]] = ByLess[Item]{}
+	var _ Comparator[ByCompare[version]] = ByCompare[version]{}
+}
+
+func TestCompareClone(t *testing.T) {
+	h, _ := NewCompareMaxHeap[version](3)
+	h.Heapify(version{1, 0}, version{2, 0}, version{0, 1})
+	c := h.Clone()
+	c.Pop()
+	require.Equal(t, 3, h.Size())
+	require.Equal(t, version{2, 0}, h.Pick())
+	require.Equal(t, version{1, 0}, c.Pick())
+
+	pq, _ := NewCompareMinPQ[version](2)
+	pq.Heapify(version{1, 0}, version{2, 0}, version{0, 1})
+	deep := pq.CloneFunc(func(item version) version { return version{item.major, item.minor + 1} })
+	deep.Push(version{0, 0})
+	require.Equal(t, 2, deep.Capacity())
+	require.Equal(t, []version{{0, 0}, {0, 2}}, deep.OrderedSlice())
+	require.Equal(t, []version{{0, 1}, {1, 0}}, pq.OrderedSlice())
+
+	var zero CompareMaxPQ[version]
+	zc := zero.Clone()
+	zc.Push(version{1, 1})
+	require.True(t, zero.Empty())
+	require.Equal(t, 1, zc.Size())
+}
+
+func TestCompareRemoveIf(t *testing.T) {
+	h, _ := NewCompareMinHeap[version](2)
+	h.Heapify(version{1, 0}, version{2, 0}, version{0, 1}, version{1, 2}, version{3, 0})
+	require.Equal(t, 2, h.RemoveIf(func(item version) bool { return item.major == 1 }))
+	require.Equal(t, 1, h.Retain(func(item version) bool { return item.minor == 0 }))
+	require.Equal(t, []version{{3, 0}}, h.Extract(func(item version) bool { return item.major > 2 }))
+	require.NoError(t, h.Validate())
+	require.Equal(t, []version{{2, 0}}, h.Slice())
+
+	pq, _ := NewCompareMaxPQ[version](3)
+	pq.Heapify(version{1, 0}, version{2, 0}, version{0, 1})
+	require.Equal(t, 1, pq.RemoveIf(func(item version) bool { return item.major == 2 }))
+	pq.Push(version{1, 2})
+	pq.Push(version{0, 0})
+	require.NoError(t, pq.Validate())
+	require.Equal(t, 3, pq.Size())
+}
@@ -0,0 +1,91 @@
+package comparable
+
+import "fmt"
+
+// Container exposes heap items as container/heap.Interface.
+// Changes made with container/heap functions are visible in heap and vice versa
+type Container[T any] struct {
+	h *baseHeap[T]
+}
+
+// container returns Container over heap. container/heap supports only binary heaps
+func (h *baseHeap[T]) container() (Container[T], error) {
+	if h.factor != 2 {
+		return Container[T]{}, fmt.Errorf("%w: %d. container/heap requires factor 2", ErrInvalidFactor, h.factor)
+	}
+	return Container[T]{h: h}, nil
+}
+
+// Len returns number of items
+func (c Container[T]) Len() int {
+	return c.h.len()
+}
+
+// Less reports whether item i has higher priority than item j
+func (c Container[T]) Less(i, j int) bool {
+	return c.h.check(c.h.at(i), c.h.at(j))
+}
+
+// Swap swaps items i and j
+func (c Container[T]) Swap(i, j int) {
+	item := c.h.at(i)
+	c.h.set(i, c.h.at(j))
+	c.h.set(j, item)
+}
+
+// Push appends item of type T, panics for other types
+func (c Container[T]) Push(item any) {
+	c.h.appendItem(item.(T))
+}
+
+// Pop removes and returns the last item
+func (c Container[T]) Pop() any {
+	last := c.h.len() - 1
+	item := c.h.at(last)
+	c.h.truncate(last)
+	return item
+}
+
+// Container returns heap as container/heap.Interface. Returns error if heap factor is not 2
+func (h *MinHeap[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
+
+// Container returns heap as container/heap.Interface. Returns error if heap factor is not 2
+func (h *MaxHeap[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
+
+// Container returns priority queue as container/heap.Interface.
+// Size bound is not applied to items pushed with container/heap
+func (h *MinPQ[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
+
+// Container returns priority queue as container/heap.Interface.
+// Size bound is not applied to items pushed with container/heap
+func (h *MaxPQ[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
+
+// Container returns heap as container/heap.Interface. Returns error if heap factor is not 2
+func (h *CompareMinHeap[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
+
+// Container returns heap as container/heap.Interface. Returns error if heap factor is not 2
+func (h *CompareMaxHeap[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
+
+// Container returns priority queue as container/heap.Interface.
+// Size bound is not applied to items pushed with container/heap
+func (h *CompareMinPQ[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
+
+// Container returns priority queue as container/heap.Interface.
+// Size bound is not applied to items pushed with container/heap
+func (h *CompareMaxPQ[T]) Container() (Container[T], error) {
+	return h.base().container()
+}
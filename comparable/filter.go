@@ -0,0 +1,331 @@
+package comparable
+
+// removeIf deletes items matching pred in place and restores heap with one heapify pass.
+// Items are saved for rollback only if any of them matches.
+// Returns number of deleted items and deleted items themselves if extract is set
+func (h *baseHeap[T]) removeIf(pred func(item T) bool, extract bool) (int, []T) {
+	size := h.len()
+	n := 0
+	for n < size && !pred(h.at(n)) {
+		n++
+	}
+	if n == size {
+		return 0, nil
+	}
+	h.save()
+	var removed []T
+	if extract {
+		removed = append(removed, h.at(n))
+	}
+	for i := n + 1; i < size; i++ {
+		item := h.at(i)
+		if pred(item) {
+			if extract {
+				removed = append(removed, item)
+			}
+			continue
+		}
+		h.put(n, item)
+		n++
+	}
+	h.drop(n)
+	h.rebuild()
+	return size - n, removed
+}
+
+// removeIf deletes items matching pred with their keys and restores heap with one heapify pass.
+// Returns number of deleted items and deleted items themselves if extract is set
+func (h *baseUniqueHeap[T, K]) removeIf(pred func(item T) bool, extract bool) (int, []T) {
+	var removed []T
+	n := 0
+	for i, item := range h.items {
+		key := h.keys[i]
+		if pred(item) {
+			delete(h.index, key)
+			if extract {
+				removed = append(removed, item)
+			}
+			continue
+		}
+		h.set(n, item, key)
+		n++
+	}
+	count := len(h.items) - n
+	if count > 0 {
+		clear(h.items[n:])
+		clear(h.keys[n:])
+		h.items, h.keys = h.items[:n], h.keys[:n]
+		h.rebuild()
+	}
+	return count, removed
+}
+
+// removeIf deletes items matching pred and subtracts their weight from total weight
+func (h *baseWeightedPQ[T]) removeIf(pred func(item T) bool, extract bool) (int, []T) {
+	return h.baseHeap.removeIf(func(item T) bool {
+		if !pred(item) {
+			return false
+		}
+		h.weight -= h.weigh(item)
+		return true
+	}, extract)
+}
+
+// retain returns predicate matching items which are not kept by pred
+func retain[T any](pred func(item T) bool) func(item T) bool {
+	return func(item T) bool {
+		return !pred(item)
+	}
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MinHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MaxHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MinPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MaxPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *CompareMinHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *CompareMaxHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *CompareMinPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *CompareMaxPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMinHeap[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMaxHeap[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMinPQ[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMaxPQ[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *WeightedMinPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *WeightedMaxPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MinHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MaxHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MinPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MaxPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *CompareMinHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *CompareMaxHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *CompareMinPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *CompareMaxPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMinHeap[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMaxHeap[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMinPQ[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMaxPQ[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *WeightedMinPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *WeightedMaxPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MinHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MaxHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MinPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MaxPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *CompareMinHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *CompareMaxHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *CompareMinPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *CompareMaxPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMinHeap[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMaxHeap[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMinPQ[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMaxPQ[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *WeightedMinPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *WeightedMaxPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
@@ -1,9 +1,14 @@
 package comparable
 
 import (
+	stdheap "container/heap"
+	"errors"
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/trezorg/heap"
 )
 
 type Item int
@@ -95,3 +100,270 @@ func TestMaxPQHeapifyWithNoData(t *testing.T) {
 	h.Heapify()
 	require.True(t, h.Empty())
 }
+
+func TestTryPopPick(t *testing.T) {
+	h, _ := NewMaxHeap[Item](3)
+	_, ok := h.TryPick()
+	require.False(t, ok)
+	_, ok = h.TryPop()
+	require.False(t, ok)
+
+	h.Push(1)
+	h.Push(2)
+	item, ok := h.TryPick()
+	require.True(t, ok)
+	require.Equal(t, Item(2), item)
+	item, ok = h.TryPop()
+	require.True(t, ok)
+	require.Equal(t, Item(2), item)
+	require.Equal(t, 1, h.Size())
+}
+
+func TestSentinelErrors(t *testing.T) {
+	_, err := NewMinHeap[Item](1)
+	require.True(t, errors.Is(err, ErrInvalidFactor))
+
+	h, _ := NewMinHeap[Item](2)
+	require.PanicsWithValue(t, ErrEmpty, func() { h.Pop() })
+}
+
+func TestZeroValue(t *testing.T) {
+	var minH MinHeap[Item]
+	minH.Heapify(3, 1, 2)
+	minH.Push(0)
+	require.Equal(t, []Item{0, 1, 2, 3}, minH.Slice())
+
+	var maxH MaxHeap[Item]
+	maxH.Push(1)
+	maxH.Push(3)
+	require.Equal(t, []Item{3, 1}, maxH.Slice())
+
+	var minP MinPQ[Item]
+	minP.Push(2)
+	minP.Push(1)
+	require.Equal(t, []Item{1, 2}, minP.OrderedSlice())
+
+	var maxP MaxPQ[Item]
+	maxP.Heapify(2, 1, 3)
+	require.Equal(t, []Item{3, 2, 1}, maxP.OrderedSlice())
+}
+
+func TestCapacity(t *testing.T) {
+	h, _ := NewMaxHeap[Item](3, WithCapacity(10))
+	require.Equal(t, 10, h.Cap())
+
+	h.Reserve(20)
+	require.GreaterOrEqual(t, h.Cap(), 20)
+	h.Heapify(1, 2, 3)
+	h.Push(4)
+	h.Shrink()
+	require.Equal(t, 4, h.Cap())
+
+	h.Clear()
+	require.True(t, h.Empty())
+	require.Equal(t, 4, h.Cap())
+	require.Equal(t, []Item{0, 0, 0, 0}, h.items[:4])
+}
+
+type pointerItem struct {
+	value int
+}
+
+func (item *pointerItem) Less(compareItem *pointerItem) bool {
+	return item.value < compareItem.value
+}
+
+func TestPopZeroesItems(t *testing.T) {
+	h, _ := NewMinHeap[*pointerItem](2)
+	one, two := &pointerItem{1}, &pointerItem{2}
+	h.Push(one)
+	h.Push(two)
+	require.Equal(t, one, h.Pop())
+	require.Nil(t, h.items[:2][1])
+}
+
+var comparisons int
+
+type countedItem int
+
+func (item countedItem) Less(compareItem countedItem) bool {
+	comparisons++
+	return item < compareItem
+}
+
+func TestBottomUpComparisons(t *testing.T) {
+	items := make([]countedItem, 10000)
+	for i, item := range rand.Perm(len(items)) {
+		items[i] = countedItem(item)
+	}
+
+	count := func(opts ...Option) ([]countedItem, int) {
+		h, _ := NewMinHeap[countedItem](2, opts...)
+		h.Heapify(append([]countedItem(nil), items...)...)
+		comparisons = 0
+		return h.Slice(), comparisons
+	}
+	expected, topDown := count()
+	slice, bottomUp := count(WithBottomUp())
+
+	require.Equal(t, expected, slice)
+	require.True(t, sort.SliceIsSorted(slice, func(i, j int) bool { return slice[i] < slice[j] }))
+	require.Less(t, bottomUp, topDown*3/4)
+}
+
+func TestContainer(t *testing.T) {
+	h, _ := NewMaxHeap[Item](2)
+	h.Heapify(5, 3, 8)
+	c, err := h.Container()
+	require.NoError(t, err)
+
+	stdheap.Push(c, Item(9))
+	h.Push(4)
+	require.Equal(t, 5, c.Len())
+	require.Equal(t, Item(9), stdheap.Pop(c))
+	require.Equal(t, Item(8), h.Pop())
+	require.Equal(t, []Item{5, 4, 3}, h.Slice())
+
+	h3, _ := NewMaxHeap[Item](3)
+	_, err = h3.Container()
+	require.True(t, errors.Is(err, ErrInvalidFactor))
+}
+
+func TestPushEvict(t *testing.T) {
+	var evicted []Item
+	pq, err := NewMaxPQ[Item](2, WithEvict(func(item Item) { evicted = append(evicted, item) }))
+	require.NoError(t, err)
+	pq.Heapify(1, 5, 3)
+	require.Equal(t, []Item{1}, evicted)
+
+	item, ok := pq.PushEvict(4)
+	require.True(t, ok)
+	require.Equal(t, Item(3), item)
+	item, ok = pq.PushEvict(2)
+	require.True(t, ok)
+	require.Equal(t, Item(2), item)
+	require.Equal(t, []Item{1, 3, 2}, evicted)
+	pq.OnEvict(nil)
+	_, ok = pq.PushEvict(6)
+	require.True(t, ok)
+	require.Len(t, evicted, 3)
+	require.Equal(t, []Item{6, 5}, pq.OrderedSlice())
+
+	_, err = NewMinPQ[Item](3, WithEvict(func(item int) {}))
+	require.Error(t, err)
+	_, err = NewMinHeap[Item](2, WithEvict(func(item Item) {}))
+	require.True(t, errors.Is(err, ErrUnsupportedOption))
+}
+
+func TestSetCapacity(t *testing.T) {
+	pq, err := NewMaxPQ[Item](4)
+	require.NoError(t, err)
+	pq.Heapify(5, 1, 4, 2, 3)
+	var callback []Item
+	pq.OnEvict(func(item Item) { callback = append(callback, item) })
+	evicted, err := pq.SetCapacity(2)
+	require.NoError(t, err)
+	require.Equal(t, []Item{2, 3}, evicted)
+	require.Equal(t, evicted, callback)
+	require.Equal(t, 2, pq.Capacity())
+	require.Equal(t, []Item{5, 4}, pq.OrderedSlice())
+
+	_, err = pq.SetCapacity(-5)
+	require.True(t, errors.Is(err, ErrInvalidSize))
+	_, err = NewMinPQ[Item](0)
+	require.True(t, errors.Is(err, ErrInvalidSize))
+	_, err = NewMinPQ[Item](Unbounded)
+	require.NoError(t, err)
+}
+
+func TestBatchOperations(t *testing.T) {
+	h, _ := NewMinHeap[Item](2)
+	h.PushMany(5, 3, 8)
+	h.PushMany(1)
+	require.Equal(t, []Item{1, 3}, h.PopN(2))
+	require.Equal(t, Item(5), h.PushPop(6))
+	require.Equal(t, Item(6), h.Replace(2))
+	require.Equal(t, []Item{2, 8}, h.Slice())
+
+	pq, _ := NewMinPQ[Item](2)
+	pq.PushMany(5, 3, 8, 1)
+	require.Equal(t, []Item{1, 3}, pq.OrderedSlice())
+}
+
+func TestRemoveIf(t *testing.T) {
+	h, _ := NewMaxHeap[Item](2)
+	h.Heapify(1, 2, 3, 4, 5, 6)
+	require.Equal(t, 3, h.RemoveIf(func(item Item) bool { return item > 3 }))
+	require.Equal(t, Item(3), h.Pick())
+
+	pq, _ := NewMaxPQ[Item](4)
+	pq.Heapify(1, 2, 3, 4, 5, 6)
+	require.Equal(t, []Item{4}, pq.Extract(func(item Item) bool { return item == 4 }))
+	require.Equal(t, 1, pq.Retain(func(item Item) bool { return item > 3 }))
+	require.Equal(t, []Item{6, 5}, pq.OrderedSlice())
+}
+
+func TestCloneFunc(t *testing.T) {
+	h, _ := NewMinHeap[*pointerItem](2)
+	h.Heapify(&pointerItem{value: 2}, &pointerItem{value: 1})
+	shallow := h.Clone()
+	deep := h.CloneFunc(func(item *pointerItem) *pointerItem {
+		c := *item
+		return &c
+	})
+	h.Pick().value = 0
+	require.Equal(t, 0, shallow.Pick().value)
+	require.Equal(t, 1, deep.Pick().value)
+
+	shallow.Pop()
+	require.Equal(t, 2, h.Size())
+	require.Equal(t, 2, deep.Size())
+}
+
+func TestCheckpoint(t *testing.T) {
+	h, _ := NewMinHeap[Item](3)
+	for _, item := range rand.Perm(30) {
+		h.Push(Item(item))
+	}
+	saved := append([]Item{}, h.items...)
+	id := h.Checkpoint()
+	for range 10 {
+		h.Pop()
+	}
+	h.PushMany(100, 50, 3)
+	h.RemoveIf(func(item Item) bool { return item%2 == 0 })
+	nested := h.Checkpoint()
+	h.Heapify()
+	require.NoError(t, h.Rollback(nested))
+	require.Equal(t, 11, h.Size())
+	require.NoError(t, h.Rollback(id))
+	require.Equal(t, saved, h.items)
+	require.True(t, errors.Is(h.Commit(id), ErrInvalidCheckpoint))
+
+	pq, _ := NewMinPQ[Item](4)
+	pq.Heapify(4, 3, 2, 1)
+	id = pq.Checkpoint()
+	_, err := pq.SetCapacity(2)
+	require.NoError(t, err)
+	require.NoError(t, pq.Rollback(id))
+	require.Equal(t, 4, pq.Capacity())
+	require.Equal(t, []Item{1, 2, 3, 4}, pq.OrderedSlice())
+}
+
+func TestValidate(t *testing.T) {
+	h, _ := NewMaxHeap[Item](4)
+	for _, item := range rand.Perm(30) {
+		h.Push(Item(item))
+	}
+	require.NoError(t, h.Validate())
+	require.Equal(t, 4, h.Factor())
+	require.Equal(t, 4, h.Height())
+	levels := h.Levels()
+	require.Equal(t, []Item{29}, levels[0])
+	require.Len(t, levels[3], 9)
+
+	h.items[10] = 100
+	var invariant *heap.InvariantError
+	require.True(t, errors.As(h.Validate(), &invariant))
+	require.Equal(t, heap.InvariantError{Parent: 2, Child: 10}, *invariant)
+}
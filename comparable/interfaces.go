@@ -0,0 +1,39 @@
+package comparable
+
+import "github.com/trezorg/heap"
+
+// checks that heaps and priority queues implement common interfaces
+func _[T Comparator[T]]() {
+	var _ heap.Heap[T] = (*MinHeap[T])(nil)
+	var _ heap.Heap[T] = (*MaxHeap[T])(nil)
+	var _ heap.BoundedQueue[T] = (*MinPQ[T])(nil)
+	var _ heap.BoundedQueue[T] = (*MaxPQ[T])(nil)
+	var _ heap.Heap[T] = (*WeightedMinPQ[T])(nil)
+	var _ heap.Heap[T] = (*WeightedMaxPQ[T])(nil)
+	var _ heap.Heap[T] = (*UniqueMinHeap[T, int])(nil)
+	var _ heap.Heap[T] = (*UniqueMaxHeap[T, int])(nil)
+	var _ heap.BoundedQueue[T] = (*UniqueMinPQ[T, int])(nil)
+	var _ heap.BoundedQueue[T] = (*UniqueMaxPQ[T, int])(nil)
+	var _ heap.Inspector[T] = (*MinHeap[T])(nil)
+	var _ heap.Inspector[T] = (*MaxHeap[T])(nil)
+	var _ heap.Inspector[T] = (*MinPQ[T])(nil)
+	var _ heap.Inspector[T] = (*MaxPQ[T])(nil)
+	var _ heap.Inspector[T] = (*WeightedMinPQ[T])(nil)
+	var _ heap.Inspector[T] = (*WeightedMaxPQ[T])(nil)
+	var _ heap.Inspector[T] = (*UniqueMinHeap[T, int])(nil)
+	var _ heap.Inspector[T] = (*UniqueMaxHeap[T, int])(nil)
+	var _ heap.Inspector[T] = (*UniqueMinPQ[T, int])(nil)
+	var _ heap.Inspector[T] = (*UniqueMaxPQ[T, int])(nil)
+}
+
+// checks that heaps and priority queues of Comparer items implement common interfaces
+func _[T Comparer[T]]() {
+	var _ heap.Heap[T] = (*CompareMinHeap[T])(nil)
+	var _ heap.Heap[T] = (*CompareMaxHeap[T])(nil)
+	var _ heap.BoundedQueue[T] = (*CompareMinPQ[T])(nil)
+	var _ heap.BoundedQueue[T] = (*CompareMaxPQ[T])(nil)
+	var _ heap.Inspector[T] = (*CompareMinHeap[T])(nil)
+	var _ heap.Inspector[T] = (*CompareMaxHeap[T])(nil)
+	var _ heap.Inspector[T] = (*CompareMinPQ[T])(nil)
+	var _ heap.Inspector[T] = (*CompareMaxPQ[T])(nil)
+}
@@ -0,0 +1,124 @@
+package comparable
+
+// sliceHeap returns heap of factor over caller owned slice s. Panics with ErrInvalidFactor if factor is less than 2
+func sliceHeap[T Comparator[T]](s []T, factor int, check func(item1 T, item2 T) bool) baseHeap[T] {
+	if err := checkFactor(factor); err != nil {
+		panic(err)
+	}
+	return baseHeap[T]{check: check, items: s, factor: factor}
+}
+
+// fix restores heap invariant for item at idx moving it up or down
+func (h *baseHeap[T]) fix(idx int) {
+	if idx > 0 && h.check(h.items[idx], h.items[h.parent(idx)]) {
+		h.up(idx)
+		return
+	}
+	h.down(idx)
+}
+
+// removeAt deletes item at index i of heap and returns it
+func (h *baseHeap[T]) removeAt(i int) T {
+	item := h.items[i]
+	last := len(h.items) - 1
+	h.items[i] = h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	if i < last {
+		h.fix(i)
+	}
+	return item
+}
+
+// isHeap either items keep heap invariant
+func (h *baseHeap[T]) isHeap() bool {
+	for i := 1; i < len(h.items); i++ {
+		if h.check(h.items[i], h.items[h.parent(i)]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Init makes min heap of factor from slice s in place.
+// Slice functions panic with ErrInvalidFactor if factor is less than 2
+func Init[T Comparator[T]](s []T, factor int) {
+	h := sliceHeap(s, factor, minCheck[T])
+	h.heapify(s...)
+}
+
+// InitMax makes max heap of factor from slice s in place.
+// Slice functions panic with ErrInvalidFactor if factor is less than 2
+func InitMax[T Comparator[T]](s []T, factor int) {
+	h := sliceHeap(s, factor, maxCheck[T])
+	h.heapify(s...)
+}
+
+// PushSlice adds x into min heap s of factor and returns the resulting slice
+func PushSlice[T Comparator[T]](s []T, x T, factor int) []T {
+	h := sliceHeap(s, factor, minCheck[T])
+	h.push(x)
+	return h.items
+}
+
+// PushSliceMax adds x into max heap s of factor and returns the resulting slice
+func PushSliceMax[T Comparator[T]](s []T, x T, factor int) []T {
+	h := sliceHeap(s, factor, maxCheck[T])
+	h.push(x)
+	return h.items
+}
+
+// PopSlice returns and deletes min value of min heap s of factor and returns the resulting slice.
+// Panics if s is empty
+func PopSlice[T Comparator[T]](s []T, factor int) (T, []T) {
+	h := sliceHeap(s, factor, minCheck[T])
+	item := h.pop()
+	return item, h.items
+}
+
+// PopSliceMax returns and deletes max value of max heap s of factor and returns the resulting slice.
+// Panics if s is empty
+func PopSliceMax[T Comparator[T]](s []T, factor int) (T, []T) {
+	h := sliceHeap(s, factor, maxCheck[T])
+	item := h.pop()
+	return item, h.items
+}
+
+// Fix restores min heap s of factor after item at index i has been changed
+func Fix[T Comparator[T]](s []T, i int, factor int) {
+	h := sliceHeap(s, factor, minCheck[T])
+	h.fix(i)
+}
+
+// FixMax restores max heap s of factor after item at index i has been changed
+func FixMax[T Comparator[T]](s []T, i int, factor int) {
+	h := sliceHeap(s, factor, maxCheck[T])
+	h.fix(i)
+}
+
+// RemoveAt returns and deletes item at index i of min heap s of factor and returns the resulting slice
+func RemoveAt[T Comparator[T]](s []T, i int, factor int) (T, []T) {
+	h := sliceHeap(s, factor, minCheck[T])
+	item := h.removeAt(i)
+	return item, h.items
+}
+
+// RemoveAtMax returns and deletes item at index i of max heap s of factor and returns the resulting slice
+func RemoveAtMax[T Comparator[T]](s []T, i int, factor int) (T, []T) {
+	h := sliceHeap(s, factor, maxCheck[T])
+	item := h.removeAt(i)
+	return item, h.items
+}
+
+// IsHeap either slice s is min heap of factor
+func IsHeap[T Comparator[T]](s []T, factor int) bool {
+	h := sliceHeap(s, factor, minCheck[T])
+	return h.isHeap()
+}
+
+// IsHeapMax either slice s is max heap of factor
+func IsHeapMax[T Comparator[T]](s []T, factor int) bool {
+	h := sliceHeap(s, factor, maxCheck[T])
+	return h.isHeap()
+}
@@ -0,0 +1,63 @@
+package comparable
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSliceHeap(t *testing.T) {
+	items := make([]Item, 1000)
+	for i, item := range rand.Perm(len(items)) {
+		items[i] = Item(item)
+	}
+	s := append([]Item(nil), items[:500]...)
+	Init(s, 3)
+	for _, item := range items[500:] {
+		s = PushSlice(s, item, 3)
+	}
+	require.True(t, IsHeap(s, 3))
+
+	s = append(s, -1)
+	Fix(s, len(s)-1, 3)
+	require.Equal(t, Item(-1), s[0])
+	_, s = RemoveAt(s, 0, 3)
+	require.True(t, IsHeap(s, 3))
+
+	sort.Slice(items, func(i, j int) bool { return items[i] < items[j] })
+	var item Item
+	for _, expected := range items {
+		item, s = PopSlice(s, 3)
+		require.Equal(t, expected, item)
+	}
+	require.Empty(t, s)
+}
+
+func TestSliceMaxHeap(t *testing.T) {
+	items := make([]Item, 1000)
+	for i, item := range rand.Perm(len(items)) {
+		items[i] = Item(item)
+	}
+	s := append([]Item(nil), items[:500]...)
+	InitMax(s, 2)
+	for _, item := range items[500:] {
+		s = PushSliceMax(s, item, 2)
+	}
+	require.True(t, IsHeapMax(s, 2))
+
+	s = append(s, 2000)
+	FixMax(s, len(s)-1, 2)
+	require.Equal(t, Item(2000), s[0])
+	_, s = RemoveAtMax(s, 0, 2)
+	require.True(t, IsHeapMax(s, 2))
+
+	var item Item
+	for expected := len(items) - 1; expected >= 0; expected-- {
+		item, s = PopSliceMax(s, 2)
+		require.Equal(t, Item(expected), item)
+	}
+	require.Empty(t, s)
+	require.PanicsWithError(t, "wrong value for factor: 1. Cannot be less than 2", func() { InitMax(s, 1) })
+}
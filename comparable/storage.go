@@ -0,0 +1,289 @@
+package comparable
+
+import (
+	"slices"
+
+	"github.com/trezorg/heap"
+)
+
+// newStorageHeap creates base heap keeping items in storage. Items already kept in storage are heapified.
+// Nil storage means items are kept in slice
+func newStorageHeap[T any](
+	factor int,
+	storage heap.Storage[T],
+	check func(item1 T, item2 T) bool,
+	queue bool,
+	opts []Option,
+) (baseHeap[T], error) {
+	h, err := newHeap(factor, check, queue, opts)
+	if err != nil || storage == nil {
+		return h, err
+	}
+	h.items, h.storage = nil, storage
+	h.rebuild()
+	return h, nil
+}
+
+// NewStorageMinHeap heap constructor. Heap keeps items in storage, items already kept there are heapified.
+// Nil storage means slice as in NewMinHeap
+func NewStorageMinHeap[T Comparator[T]](factor int, storage heap.Storage[T], opts ...Option) (MinHeap[T], error) {
+	baseHeap, err := newStorageHeap(factor, storage, minCheck[T], false, opts)
+	if err != nil {
+		return MinHeap[T]{}, err
+	}
+	return MinHeap[T]{baseHeap}, nil
+}
+
+// NewStorageMaxHeap heap constructor. Heap keeps items in storage, items already kept there are heapified.
+// Nil storage means slice as in NewMaxHeap
+func NewStorageMaxHeap[T Comparator[T]](factor int, storage heap.Storage[T], opts ...Option) (MaxHeap[T], error) {
+	baseHeap, err := newStorageHeap(factor, storage, maxCheck[T], false, opts)
+	if err != nil {
+		return MaxHeap[T]{}, err
+	}
+	return MaxHeap[T]{baseHeap}, nil
+}
+
+// NewStorageMaxPQ creates maximum priority Queue with heap factor 2 keeping items in storage.
+// Items already kept in storage are heapified, the ones exceeding size are evicted.
+// Nil storage means slice as in NewMaxPQ. Size should be positive or Unbounded
+func NewStorageMaxPQ[T Comparator[T]](size int, storage heap.Storage[T], opts ...Option) (MaxPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return MaxPQ[T]{}, err
+	}
+	baseHeap, err := newStorageHeap(2, storage, minCheck[T], true, opts)
+	if err != nil {
+		return MaxPQ[T]{}, err
+	}
+	baseHeap.shrinkTo(size)
+	return MaxPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+// NewStorageMinPQ creates minimum priority Queue with heap factor 2 keeping items in storage.
+// Items already kept in storage are heapified, the ones exceeding size are evicted.
+// Nil storage means slice as in NewMinPQ. Size should be positive or Unbounded
+func NewStorageMinPQ[T Comparator[T]](size int, storage heap.Storage[T], opts ...Option) (MinPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return MinPQ[T]{}, err
+	}
+	baseHeap, err := newStorageHeap(2, storage, maxCheck[T], true, opts)
+	if err != nil {
+		return MinPQ[T]{}, err
+	}
+	baseHeap.shrinkTo(size)
+	return MinPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+// NewStorageCompareMinHeap heap constructor for Comparer items. Heap keeps items in storage,
+// items already kept there are heapified. Nil storage means slice as in NewCompareMinHeap
+func NewStorageCompareMinHeap[T Comparer[T]](factor int, storage heap.Storage[T], opts ...Option) (CompareMinHeap[T], error) {
+	baseHeap, err := newStorageHeap(factor, storage, compareMinCheck[T], false, opts)
+	if err != nil {
+		return CompareMinHeap[T]{}, err
+	}
+	return CompareMinHeap[T]{baseHeap}, nil
+}
+
+// NewStorageCompareMaxHeap heap constructor for Comparer items. Heap keeps items in storage,
+// items already kept there are heapified. Nil storage means slice as in NewCompareMaxHeap
+func NewStorageCompareMaxHeap[T Comparer[T]](factor int, storage heap.Storage[T], opts ...Option) (CompareMaxHeap[T], error) {
+	baseHeap, err := newStorageHeap(factor, storage, compareMaxCheck[T], false, opts)
+	if err != nil {
+		return CompareMaxHeap[T]{}, err
+	}
+	return CompareMaxHeap[T]{baseHeap}, nil
+}
+
+// NewStorageCompareMaxPQ creates maximum priority queue of Comparer items with heap factor 2 keeping items in storage.
+// Items already kept in storage are heapified, the ones exceeding size are evicted.
+// Nil storage means slice as in NewCompareMaxPQ. Size should be positive or Unbounded
+func NewStorageCompareMaxPQ[T Comparer[T]](size int, storage heap.Storage[T], opts ...Option) (CompareMaxPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return CompareMaxPQ[T]{}, err
+	}
+	baseHeap, err := newStorageHeap(2, storage, compareMinCheck[T], true, opts)
+	if err != nil {
+		return CompareMaxPQ[T]{}, err
+	}
+	baseHeap.shrinkTo(size)
+	return CompareMaxPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+// NewStorageCompareMinPQ creates minimum priority queue of Comparer items with heap factor 2 keeping items in storage.
+// Items already kept in storage are heapified, the ones exceeding size are evicted.
+// Nil storage means slice as in NewCompareMinPQ. Size should be positive or Unbounded
+func NewStorageCompareMinPQ[T Comparer[T]](size int, storage heap.Storage[T], opts ...Option) (CompareMinPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return CompareMinPQ[T]{}, err
+	}
+	baseHeap, err := newStorageHeap(2, storage, compareMaxCheck[T], true, opts)
+	if err != nil {
+		return CompareMinPQ[T]{}, err
+	}
+	baseHeap.shrinkTo(size)
+	return CompareMinPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+// at returns item at idx
+func (h *baseHeap[T]) at(idx int) T {
+	if h.storage != nil {
+		return h.storage.Get(idx)
+	}
+	return h.items[idx]
+}
+
+// put replaces item at idx without logging
+func (h *baseHeap[T]) put(idx int, item T) {
+	if h.storage != nil {
+		h.storage.Set(idx, item)
+		return
+	}
+	h.items[idx] = item
+}
+
+// extend adds items after the last one without logging
+func (h *baseHeap[T]) extend(items []T) {
+	if h.storage == nil {
+		h.items = append(h.items, items...)
+		return
+	}
+	for _, item := range items {
+		h.storage.Append(item)
+	}
+}
+
+// drop deletes items starting from n without logging. Deleted items are zeroed to be garbage collected
+func (h *baseHeap[T]) drop(n int) {
+	if h.storage != nil {
+		h.storage.Truncate(n)
+		return
+	}
+	clear(h.items[n:])
+	h.items = h.items[:n]
+}
+
+// load replaces items without logging. Slice is kept as is, items are copied into storage
+func (h *baseHeap[T]) load(items []T) {
+	if h.storage == nil {
+		h.items = items
+		return
+	}
+	h.storage.Truncate(0)
+	h.extend(items)
+}
+
+// snapshot returns copy of items
+func (h *baseHeap[T]) snapshot() []T {
+	if h.storage == nil {
+		return slices.Clone(h.items)
+	}
+	res := make([]T, h.storage.Len())
+	for i := range res {
+		res[i] = h.storage.Get(i)
+	}
+	return res
+}
+
+// popStorage returns and deletes the top item of storage
+func (h *baseHeap[T]) popStorage() T {
+	last := h.storage.Len() - 1
+	item := h.storage.Get(0)
+	h.set(0, h.storage.Get(last))
+	h.truncate(last)
+	h.down(0)
+	return item
+}
+
+// siftUpStorage moves item at idx of storage up and returns its new index
+func (h *baseHeap[T]) siftUpStorage(idx int) int {
+	s := h.storage
+	item := s.Get(idx)
+	for idx > 0 {
+		parent := h.parent(idx)
+		parentItem := s.Get(parent)
+		if !h.check(item, parentItem) {
+			break
+		}
+		s.Set(idx, parentItem)
+		idx = parent
+	}
+	s.Set(idx, item)
+	return idx
+}
+
+// siftDownStorage moves item at idx of storage down choosing the best of factor children
+// and returns its new index. Bottom up sifting moves hole to a leaf and then sifts item up
+func (h *baseHeap[T]) siftDownStorage(idx int) int {
+	s := h.storage
+	n := s.Len()
+	item := s.Get(idx)
+	start := idx
+	for {
+		first, last := h.children(idx)
+		if first >= n {
+			break
+		}
+		child, childItem := first, s.Get(first)
+		for i := first + 1; i < min(last, n); i++ {
+			if other := s.Get(i); h.check(other, childItem) {
+				child, childItem = i, other
+			}
+		}
+		if !h.bottomUp && !h.check(childItem, item) {
+			break
+		}
+		s.Set(idx, childItem)
+		idx = child
+	}
+	for h.bottomUp && idx > start {
+		parent := h.parent(idx)
+		parentItem := s.Get(parent)
+		if !h.check(item, parentItem) {
+			break
+		}
+		s.Set(idx, parentItem)
+		idx = parent
+	}
+	s.Set(idx, item)
+	return idx
+}
+
+// Storage returns storage keeping heap items. Returns nil if items are kept in slice
+func (h *MinHeap[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping heap items. Returns nil if items are kept in slice
+func (h *MaxHeap[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping priority queue items. Returns nil if items are kept in slice
+func (h *MinPQ[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping priority queue items. Returns nil if items are kept in slice
+func (h *MaxPQ[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping heap items. Returns nil if items are kept in slice
+func (h *CompareMinHeap[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping heap items. Returns nil if items are kept in slice
+func (h *CompareMaxHeap[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping priority queue items. Returns nil if items are kept in slice
+func (h *CompareMinPQ[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping priority queue items. Returns nil if items are kept in slice
+func (h *CompareMaxPQ[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
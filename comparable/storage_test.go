@@ -0,0 +1,48 @@
+package comparable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trezorg/heap"
+)
+
+func TestStorageHeap(t *testing.T) {
+	storage, _ := heap.NewChunkedStorage[Item](4)
+	for _, item := range []Item{5, 3, 9, 1, 7, 2, 8} {
+		storage.Append(item)
+	}
+	h, err := NewStorageMaxHeap[Item](3, storage)
+	require.NoError(t, err)
+	require.Equal(t, Item(9), h.Pick())
+	h.Push(10)
+	require.Equal(t, []Item{10, 9, 8, 7, 5, 3, 2, 1}, h.Slice())
+	require.Equal(t, 0, storage.Len())
+
+	minH, err := NewStorageMinHeap[Item](2, nil)
+	require.NoError(t, err)
+	require.Nil(t, minH.Storage())
+	minH.Heapify(3, 1, 2)
+	require.Equal(t, Item(1), minH.Pop())
+	require.Equal(t, 2, minH.Size())
+}
+
+func TestStoragePQ(t *testing.T) {
+	storage := &heap.SliceStorage[Item]{}
+	for _, item := range []Item{5, 3, 9, 1, 7, 2, 8} {
+		storage.Append(item)
+	}
+	var evicted []Item
+	pq, err := NewStorageMinPQ[Item](3, storage, WithEvict(func(item Item) { evicted = append(evicted, item) }))
+	require.NoError(t, err)
+	require.Equal(t, 3, storage.Len())
+	require.Equal(t, []Item{9, 8, 7, 5}, evicted)
+	pq.Push(0)
+	pq.PushMany(4, 6)
+	require.NoError(t, pq.Validate())
+	cp := pq.Checkpoint()
+	pq.Clear()
+	require.NoError(t, pq.Rollback(cp))
+	require.Equal(t, []Item{0, 1, 2}, pq.OrderedSlice())
+	require.Equal(t, 0, storage.Len())
+}
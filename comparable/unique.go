@@ -0,0 +1,722 @@
+package comparable
+
+import "errors"
+
+// ErrMergeKey is panicked with when merge function returns item with key differing from the key of kept item
+var ErrMergeKey = errors.New("merged item key differs from kept item key")
+
+// DuplicatePolicy defines how unique heap handles pushed item with the key of already kept item
+type DuplicatePolicy int
+
+const (
+	// IgnoreDuplicate keeps the kept item and drops the pushed one
+	IgnoreDuplicate DuplicatePolicy = iota
+	// ReplaceBetter replaces the kept item if the pushed one has better priority
+	ReplaceBetter
+	// ReplaceDuplicate always replaces the kept item with the pushed one
+	ReplaceDuplicate
+	// MergeDuplicate replaces the kept item with result of merge function set by WithMerge
+	MergeDuplicate
+)
+
+// baseUniqueHeap heap structure keeping at most one item per key.
+// Keys of items are kept in parallel slice and index maps them to item positions
+type baseUniqueHeap[T Comparator[T], K comparable] struct {
+	baseHeap[T]
+	keys       []K
+	index      map[K]int
+	key        func(item T) K
+	merge      func(kept, pushed T) T
+	duplicates DuplicatePolicy
+	queue      bool
+}
+
+// UniqueMinHeap is heap that returns element with min priority and keeps at most one item per key.
+// Zero value is not usable, use NewUniqueMinHeap
+type UniqueMinHeap[T Comparator[T], K comparable] struct {
+	baseUniqueHeap[T, K]
+}
+
+// UniqueMaxHeap is heap that returns element with max priority and keeps at most one item per key.
+// Zero value is not usable, use NewUniqueMaxHeap
+type UniqueMaxHeap[T Comparator[T], K comparable] struct {
+	baseUniqueHeap[T, K]
+}
+
+// UniqueMinPQ is minimum bounded priority queue keeping at most one item per key.
+// Zero value is not usable, use NewUniqueMinPQ
+type UniqueMinPQ[T Comparator[T], K comparable] struct {
+	baseUniqueHeap[T, K]
+	size int
+}
+
+// UniqueMaxPQ is maximum bounded priority queue keeping at most one item per key.
+// Zero value is not usable, use NewUniqueMaxPQ
+type UniqueMaxPQ[T Comparator[T], K comparable] struct {
+	baseUniqueHeap[T, K]
+	size int
+}
+
+func newUniqueHeap[T Comparator[T], K comparable](
+	factor int,
+	key func(item T) K,
+	check func(item1 T, item2 T) bool,
+	queue bool,
+	opts []Option,
+) (baseUniqueHeap[T, K], error) {
+	if key == nil {
+		return baseUniqueHeap[T, K]{}, errors.New("key function is required")
+	}
+	baseHeap, err := newHeap(factor, check, queue, opts)
+	if err != nil {
+		return baseUniqueHeap[T, K]{}, err
+	}
+	o := newOptions(opts)
+	merge, err := mergeFunc[T](o)
+	if err != nil {
+		return baseUniqueHeap[T, K]{}, err
+	}
+	return baseUniqueHeap[T, K]{
+		baseHeap:   baseHeap,
+		keys:       make([]K, 0, o.capacity),
+		index:      make(map[K]int, o.capacity),
+		key:        key,
+		merge:      merge,
+		duplicates: o.duplicates,
+		queue:      queue,
+	}, nil
+}
+
+// NewUniqueMinHeap heap constructor. Key returns identity of item
+func NewUniqueMinHeap[T Comparator[T], K comparable](
+	factor int,
+	key func(item T) K,
+	opts ...Option,
+) (UniqueMinHeap[T, K], error) {
+	baseHeap, err := newUniqueHeap(factor, key, minCheck[T], false, opts)
+	if err != nil {
+		return UniqueMinHeap[T, K]{}, err
+	}
+	return UniqueMinHeap[T, K]{baseHeap}, nil
+}
+
+// NewUniqueMaxHeap heap constructor. Key returns identity of item
+func NewUniqueMaxHeap[T Comparator[T], K comparable](
+	factor int,
+	key func(item T) K,
+	opts ...Option,
+) (UniqueMaxHeap[T, K], error) {
+	baseHeap, err := newUniqueHeap(factor, key, maxCheck[T], false, opts)
+	if err != nil {
+		return UniqueMaxHeap[T, K]{}, err
+	}
+	return UniqueMaxHeap[T, K]{baseHeap}, nil
+}
+
+// NewUniqueMinPQ creates minimum priority queue with heap factor 2. Key returns identity of item.
+// Size should be positive or Unbounded
+func NewUniqueMinPQ[T Comparator[T], K comparable](
+	size int,
+	key func(item T) K,
+	opts ...Option,
+) (UniqueMinPQ[T, K], error) {
+	if err := checkSize(size); err != nil {
+		return UniqueMinPQ[T, K]{}, err
+	}
+	baseHeap, err := newUniqueHeap(2, key, maxCheck[T], true, opts)
+	if err != nil {
+		return UniqueMinPQ[T, K]{}, err
+	}
+	return UniqueMinPQ[T, K]{baseUniqueHeap: baseHeap, size: size}, nil
+}
+
+// NewUniqueMaxPQ creates maximum priority queue with heap factor 2. Key returns identity of item.
+// Size should be positive or Unbounded
+func NewUniqueMaxPQ[T Comparator[T], K comparable](
+	size int,
+	key func(item T) K,
+	opts ...Option,
+) (UniqueMaxPQ[T, K], error) {
+	if err := checkSize(size); err != nil {
+		return UniqueMaxPQ[T, K]{}, err
+	}
+	baseHeap, err := newUniqueHeap(2, key, minCheck[T], true, opts)
+	if err != nil {
+		return UniqueMaxPQ[T, K]{}, err
+	}
+	return UniqueMaxPQ[T, K]{baseUniqueHeap: baseHeap, size: size}, nil
+}
+
+// better either item1 has better priority than item2
+func (h *baseUniqueHeap[T, K]) better(item1 T, item2 T) bool {
+	if h.queue {
+		return h.check(item2, item1)
+	}
+	return h.check(item1, item2)
+}
+
+// set puts item with key at idx and updates index
+func (h *baseUniqueHeap[T, K]) set(idx int, item T, key K) {
+	h.items[idx] = item
+	h.keys[idx] = key
+	h.index[key] = idx
+}
+
+func (h *baseUniqueHeap[T, K]) up(idx int) {
+	item, key := h.items[idx], h.keys[idx]
+	for idx > 0 {
+		parent := (idx - 1) / h.factor
+		if !h.check(item, h.items[parent]) {
+			break
+		}
+		h.set(idx, h.items[parent], h.keys[parent])
+		idx = parent
+	}
+	h.set(idx, item, key)
+}
+
+// down moves item at idx down choosing the best of factor children.
+// Bottom up sifting moves hole to a leaf and then sifts item up
+func (h *baseUniqueHeap[T, K]) down(idx int) {
+	items := h.items
+	item, key := items[idx], h.keys[idx]
+	start := idx
+	for {
+		first := idx*h.factor + 1
+		if first >= len(items) {
+			break
+		}
+		child := first
+		for i := first + 1; i < min(first+h.factor, len(items)); i++ {
+			if h.check(items[i], items[child]) {
+				child = i
+			}
+		}
+		if !h.bottomUp && !h.check(items[child], item) {
+			break
+		}
+		h.set(idx, items[child], h.keys[child])
+		idx = child
+	}
+	for h.bottomUp && idx > start {
+		parent := (idx - 1) / h.factor
+		if !h.check(item, items[parent]) {
+			break
+		}
+		h.set(idx, items[parent], h.keys[parent])
+		idx = parent
+	}
+	h.set(idx, item, key)
+}
+
+// fix restores heap invariant for item at idx moving it up or down
+func (h *baseUniqueHeap[T, K]) fix(idx int) {
+	if idx > 0 && h.check(h.items[idx], h.items[(idx-1)/h.factor]) {
+		h.up(idx)
+		return
+	}
+	h.down(idx)
+}
+
+// resolve returns item kept for key after pushed duplicate of kept item according to duplicate policy.
+// Returns false if kept item stays unchanged. Panics with ErrMergeKey if merged item key is not key
+func (h *baseUniqueHeap[T, K]) resolve(kept, pushed T, key K) (T, bool) {
+	switch h.duplicates {
+	case ReplaceBetter:
+		return pushed, h.better(pushed, kept)
+	case ReplaceDuplicate:
+		return pushed, true
+	case MergeDuplicate:
+		merged := h.merge(kept, pushed)
+		if h.key(merged) != key {
+			panic(ErrMergeKey)
+		}
+		return merged, true
+	default:
+		return kept, false
+	}
+}
+
+// duplicate handles pushed item with the key of kept item. Returns false if key is not kept
+func (h *baseUniqueHeap[T, K]) duplicate(item T, key K) bool {
+	idx, ok := h.index[key]
+	if !ok {
+		return false
+	}
+	if item, ok := h.resolve(h.items[idx], item, key); ok {
+		h.items[idx] = item
+		h.fix(idx)
+	}
+	return true
+}
+
+func (h *baseUniqueHeap[T, K]) push(item T) {
+	key := h.key(item)
+	if h.duplicate(item, key) {
+		return
+	}
+	h.items = append(h.items, item)
+	h.keys = append(h.keys, key)
+	h.up(len(h.items) - 1)
+}
+
+// pushBounded adds item into priority queue of size. When priority queue is full,
+// either item or the top one with the worst priority is evicted. Returns evicted item
+func (h *baseUniqueHeap[T, K]) pushBounded(size int, item T) (T, bool) {
+	key := h.key(item)
+	var zero T
+	if h.duplicate(item, key) {
+		return zero, false
+	}
+	if !h.full(size) {
+		h.items = append(h.items, item)
+		h.keys = append(h.keys, key)
+		h.up(len(h.items) - 1)
+		return zero, false
+	}
+	if h.check(item, h.items[0]) {
+		h.evicted(item)
+		return item, true
+	}
+	evicted := h.items[0]
+	delete(h.index, h.keys[0])
+	h.set(0, item, key)
+	h.down(0)
+	h.evicted(evicted)
+	return evicted, true
+}
+
+// remove deletes and returns item at idx
+func (h *baseUniqueHeap[T, K]) remove(idx int) T {
+	item := h.items[idx]
+	delete(h.index, h.keys[idx])
+	last := len(h.items) - 1
+	if idx < last {
+		h.set(idx, h.items[last], h.keys[last])
+	}
+	var zero T
+	var zeroKey K
+	h.items[last], h.keys[last] = zero, zeroKey
+	h.items, h.keys = h.items[:last], h.keys[:last]
+	if idx < last {
+		h.fix(idx)
+	}
+	return item
+}
+
+func (h *baseUniqueHeap[T, K]) pop() T {
+	if h.empty() {
+		panic(ErrEmpty)
+	}
+	return h.remove(0)
+}
+
+func (h *baseUniqueHeap[T, K]) tryPop() (T, bool) {
+	if h.empty() {
+		var item T
+		return item, false
+	}
+	return h.pop(), true
+}
+
+// heapify initializes heap with copy of items resolving duplicates according to duplicate policy.
+// Items slice is kept unchanged
+func (h *baseUniqueHeap[T, K]) heapify(items ...T) {
+	clear(h.index)
+	clear(h.items)
+	clear(h.keys)
+	h.items, h.keys = h.items[:0], h.keys[:0]
+	for _, item := range items {
+		key := h.key(item)
+		if idx, ok := h.index[key]; ok {
+			if item, ok := h.resolve(h.items[idx], item, key); ok {
+				h.items[idx] = item
+			}
+			continue
+		}
+		h.index[key] = len(h.items)
+		h.items = append(h.items, item)
+		h.keys = append(h.keys, key)
+	}
+	h.rebuild()
+}
+
+// rebuild restores heap invariant of all items keeping index in sync
+func (h *baseUniqueHeap[T, K]) rebuild() {
+	n := len(h.items)
+	for i := (n - 1) / h.factor; i >= 0 && n > 0; i-- {
+		h.down(i)
+	}
+}
+
+// heapifyBounded initializes priority queue of size evicting items with the worst priority
+func (h *baseUniqueHeap[T, K]) heapifyBounded(size int, items ...T) {
+	h.heapify(items...)
+	for h.full(size) && h.len() > size {
+		h.evicted(h.pop())
+	}
+}
+
+// shrinkTo evicts items until priority queue of size is not overfilled. Returns evicted items
+func (h *baseUniqueHeap[T, K]) shrinkTo(size int) []T {
+	if size < 1 || h.len() <= size {
+		return nil
+	}
+	evicted := make([]T, 0, h.len()-size)
+	for h.len() > size {
+		item := h.pop()
+		h.evicted(item)
+		evicted = append(evicted, item)
+	}
+	return evicted
+}
+
+// get returns item kept for key
+func (h *baseUniqueHeap[T, K]) get(key K) (T, bool) {
+	idx, ok := h.index[key]
+	if !ok {
+		var item T
+		return item, false
+	}
+	return h.items[idx], true
+}
+
+// removeKey deletes and returns item kept for key
+func (h *baseUniqueHeap[T, K]) removeKey(key K) (T, bool) {
+	idx, ok := h.index[key]
+	if !ok {
+		var item T
+		return item, false
+	}
+	return h.remove(idx), true
+}
+
+func (h *baseUniqueHeap[T, K]) slice() []T {
+	res := make([]T, 0, h.len())
+	for !h.empty() {
+		res = append(res, h.pop())
+	}
+	return res
+}
+
+func (h *baseUniqueHeap[T, K]) orderedSlice() []T {
+	res := make([]T, h.len())
+	for i := h.len() - 1; i >= 0; i-- {
+		res[i] = h.pop()
+	}
+	return res
+}
+
+// Push adds item into heap. Pushed duplicate is handled according to duplicate policy
+func (h *UniqueMinHeap[T, K]) Push(item T) {
+	h.push(item)
+}
+
+// Push adds item into heap. Pushed duplicate is handled according to duplicate policy
+func (h *UniqueMaxHeap[T, K]) Push(item T) {
+	h.push(item)
+}
+
+// Push adds item into priority queue. Pushed duplicate is handled according to duplicate policy
+func (h *UniqueMinPQ[T, K]) Push(item T) {
+	h.pushBounded(h.size, item)
+}
+
+// Push adds item into priority queue. Pushed duplicate is handled according to duplicate policy
+func (h *UniqueMaxPQ[T, K]) Push(item T) {
+	h.pushBounded(h.size, item)
+}
+
+// PushEvict adds item into priority queue. When priority queue is full, returns evicted item,
+// which is either item itself or the current max value
+func (h *UniqueMinPQ[T, K]) PushEvict(item T) (T, bool) {
+	return h.pushBounded(h.size, item)
+}
+
+// OnEvict sets callback receiving items evicted from priority queue on Push, PushEvict and Heapify.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *UniqueMinPQ[T, K]) OnEvict(evict func(item T)) {
+	h.evict = evict
+}
+
+// PushEvict adds item into priority queue. When priority queue is full, returns evicted item,
+// which is either item itself or the current min value
+func (h *UniqueMaxPQ[T, K]) PushEvict(item T) (T, bool) {
+	return h.pushBounded(h.size, item)
+}
+
+// OnEvict sets callback receiving items evicted from priority queue on Push, PushEvict and Heapify.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *UniqueMaxPQ[T, K]) OnEvict(evict func(item T)) {
+	h.evict = evict
+}
+
+// Pop returns and deletes min value
+func (h *UniqueMinHeap[T, K]) Pop() T {
+	return h.pop()
+}
+
+// Pop returns and deletes max value
+func (h *UniqueMaxHeap[T, K]) Pop() T {
+	return h.pop()
+}
+
+// Pop returns and deletes max value
+func (h *UniqueMinPQ[T, K]) Pop() T {
+	return h.pop()
+}
+
+// Pop returns and deletes min value
+func (h *UniqueMaxPQ[T, K]) Pop() T {
+	return h.pop()
+}
+
+// TryPop returns and deletes min value. Returns false if heap is empty
+func (h *UniqueMinHeap[T, K]) TryPop() (T, bool) {
+	return h.tryPop()
+}
+
+// TryPop returns and deletes max value. Returns false if heap is empty
+func (h *UniqueMaxHeap[T, K]) TryPop() (T, bool) {
+	return h.tryPop()
+}
+
+// TryPop returns and deletes max value. Returns false if priority queue is empty
+func (h *UniqueMinPQ[T, K]) TryPop() (T, bool) {
+	return h.tryPop()
+}
+
+// TryPop returns and deletes min value. Returns false if priority queue is empty
+func (h *UniqueMaxPQ[T, K]) TryPop() (T, bool) {
+	return h.tryPop()
+}
+
+// Pick returns min value
+func (h *UniqueMinHeap[T, K]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns max value
+func (h *UniqueMaxHeap[T, K]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns max value
+func (h *UniqueMinPQ[T, K]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns min value
+func (h *UniqueMaxPQ[T, K]) Pick() T {
+	return h.pick()
+}
+
+// TryPick returns min value. Returns false if heap is empty
+func (h *UniqueMinHeap[T, K]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns max value. Returns false if heap is empty
+func (h *UniqueMaxHeap[T, K]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns max value. Returns false if priority queue is empty
+func (h *UniqueMinPQ[T, K]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns min value. Returns false if priority queue is empty
+func (h *UniqueMaxPQ[T, K]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// Empty either heap is blank
+func (h *UniqueMinHeap[T, K]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either heap is blank
+func (h *UniqueMaxHeap[T, K]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either priority queue is blank
+func (h *UniqueMinPQ[T, K]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either priority queue is blank
+func (h *UniqueMaxPQ[T, K]) Empty() bool {
+	return h.empty()
+}
+
+// Size returns heap size
+func (h *UniqueMinHeap[T, K]) Size() int {
+	return h.len()
+}
+
+// Size returns heap size
+func (h *UniqueMaxHeap[T, K]) Size() int {
+	return h.len()
+}
+
+// Size returns priority queue size
+func (h *UniqueMinPQ[T, K]) Size() int {
+	return h.len()
+}
+
+// Size returns priority queue size
+func (h *UniqueMaxPQ[T, K]) Size() int {
+	return h.len()
+}
+
+// Heapify initializes heap. Duplicates are handled according to duplicate policy
+func (h *UniqueMinHeap[T, K]) Heapify(items ...T) {
+	h.heapify(items...)
+}
+
+// Heapify initializes heap. Duplicates are handled according to duplicate policy
+func (h *UniqueMaxHeap[T, K]) Heapify(items ...T) {
+	h.heapify(items...)
+}
+
+// Heapify initializes priority queue. Duplicates are handled according to duplicate policy
+func (h *UniqueMinPQ[T, K]) Heapify(items ...T) {
+	h.heapifyBounded(h.size, items...)
+}
+
+// Heapify initializes priority queue. Duplicates are handled according to duplicate policy
+func (h *UniqueMaxPQ[T, K]) Heapify(items ...T) {
+	h.heapifyBounded(h.size, items...)
+}
+
+// Contains either heap keeps item with key
+func (h *UniqueMinHeap[T, K]) Contains(key K) bool {
+	_, ok := h.index[key]
+	return ok
+}
+
+// Contains either heap keeps item with key
+func (h *UniqueMaxHeap[T, K]) Contains(key K) bool {
+	_, ok := h.index[key]
+	return ok
+}
+
+// Contains either priority queue keeps item with key
+func (h *UniqueMinPQ[T, K]) Contains(key K) bool {
+	_, ok := h.index[key]
+	return ok
+}
+
+// Contains either priority queue keeps item with key
+func (h *UniqueMaxPQ[T, K]) Contains(key K) bool {
+	_, ok := h.index[key]
+	return ok
+}
+
+// Get returns item with key. Returns false if there is no such item
+func (h *UniqueMinHeap[T, K]) Get(key K) (T, bool) {
+	return h.get(key)
+}
+
+// Get returns item with key. Returns false if there is no such item
+func (h *UniqueMaxHeap[T, K]) Get(key K) (T, bool) {
+	return h.get(key)
+}
+
+// Get returns item with key. Returns false if there is no such item
+func (h *UniqueMinPQ[T, K]) Get(key K) (T, bool) {
+	return h.get(key)
+}
+
+// Get returns item with key. Returns false if there is no such item
+func (h *UniqueMaxPQ[T, K]) Get(key K) (T, bool) {
+	return h.get(key)
+}
+
+// Remove deletes and returns item with key. Returns false if there is no such item
+func (h *UniqueMinHeap[T, K]) Remove(key K) (T, bool) {
+	return h.removeKey(key)
+}
+
+// Remove deletes and returns item with key. Returns false if there is no such item
+func (h *UniqueMaxHeap[T, K]) Remove(key K) (T, bool) {
+	return h.removeKey(key)
+}
+
+// Remove deletes and returns item with key. Returns false if there is no such item
+func (h *UniqueMinPQ[T, K]) Remove(key K) (T, bool) {
+	return h.removeKey(key)
+}
+
+// Remove deletes and returns item with key. Returns false if there is no such item
+func (h *UniqueMaxPQ[T, K]) Remove(key K) (T, bool) {
+	return h.removeKey(key)
+}
+
+// Slice returns heap slice
+func (h *UniqueMinHeap[T, K]) Slice() []T {
+	return h.slice()
+}
+
+// Slice returns heap slice
+func (h *UniqueMaxHeap[T, K]) Slice() []T {
+	return h.slice()
+}
+
+// Slice returns priority queue slice
+func (h *UniqueMinPQ[T, K]) Slice() []T {
+	return h.slice()
+}
+
+// Slice returns priority queue slice
+func (h *UniqueMaxPQ[T, K]) Slice() []T {
+	return h.slice()
+}
+
+// OrderedSlice returns priority queue slice ordered by priority
+func (h *UniqueMinPQ[T, K]) OrderedSlice() []T {
+	return h.orderedSlice()
+}
+
+// OrderedSlice returns priority queue slice ordered by priority
+func (h *UniqueMaxPQ[T, K]) OrderedSlice() []T {
+	return h.orderedSlice()
+}
+
+// Capacity returns priority queue size bound or Unbounded
+func (h *UniqueMinPQ[T, K]) Capacity() int {
+	if h.size < 1 {
+		return Unbounded
+	}
+	return h.size
+}
+
+// Capacity returns priority queue size bound or Unbounded
+func (h *UniqueMaxPQ[T, K]) Capacity() int {
+	if h.size < 1 {
+		return Unbounded
+	}
+	return h.size
+}
+
+// SetCapacity changes priority queue size bound. Shrinking evicts items starting from the max value.
+// Evicted items are passed to eviction callback and returned in eviction order. Size should be positive or Unbounded
+func (h *UniqueMinPQ[T, K]) SetCapacity(size int) ([]T, error) {
+	if err := checkSize(size); err != nil {
+		return nil, err
+	}
+	h.size = size
+	return h.shrinkTo(size), nil
+}
+
+// SetCapacity changes priority queue size bound. Shrinking evicts items starting from the min value.
+// Evicted items are passed to eviction callback and returned in eviction order. Size should be positive or Unbounded
+func (h *UniqueMaxPQ[T, K]) SetCapacity(size int) ([]T, error) {
+	if err := checkSize(size); err != nil {
+		return nil, err
+	}
+	h.size = size
+	return h.shrinkTo(size), nil
+}
@@ -0,0 +1,72 @@
+package comparable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type page struct {
+	url   string
+	depth int
+}
+
+func (p page) Less(other page) bool {
+	return p.depth < other.depth
+}
+
+func TestUniqueHeap(t *testing.T) {
+	url := func(p page) string { return p.url }
+	h, err := NewUniqueMinHeap(2, url, WithDuplicates(ReplaceBetter))
+	require.NoError(t, err)
+	h.Push(page{"a", 3})
+	h.Push(page{"b", 2})
+	h.Push(page{"a", 1})
+	h.Push(page{"b", 5})
+	require.Equal(t, 2, h.Size())
+	require.Equal(t, page{"a", 1}, h.Pop())
+	require.False(t, h.Contains("a"))
+	p, ok := h.Get("b")
+	require.True(t, ok)
+	require.Equal(t, page{"b", 2}, p)
+
+	pq, _ := NewUniqueMaxPQ(2, url, WithMerge(func(kept, pushed page) page {
+		return page{kept.url, kept.depth + pushed.depth}
+	}))
+	pages := []page{{"a", 1}, {"b", 2}, {"a", 2}, {"c", 1}}
+	pq.Heapify(pages...)
+	require.Equal(t, []page{{"a", 1}, {"b", 2}, {"a", 2}, {"c", 1}}, pages)
+	require.Equal(t, []page{{"a", 3}, {"b", 2}}, pq.OrderedSlice())
+
+	pq, _ = NewUniqueMaxPQ(2, url, WithMerge(func(kept, pushed page) page { return pushed }))
+	pq.Push(page{"a", 1})
+	require.NotPanics(t, func() { pq.Push(page{"a", 2}) })
+	pq, _ = NewUniqueMaxPQ(2, url, WithMerge(func(kept, pushed page) page { return page{"b", kept.depth} }))
+	pq.Push(page{"a", 1})
+	require.PanicsWithValue(t, ErrMergeKey, func() { pq.Push(page{"a", 2}) })
+}
+
+func TestUniqueHeapBottomUp(t *testing.T) {
+	h, _ := NewUniqueMaxHeap(3, func(item Item) Item { return item % 50 }, WithBottomUp())
+	for i := 0; i < 1000; i++ {
+		h.Push(Item(i))
+	}
+	require.NoError(t, h.Validate())
+	require.Equal(t, 50, h.Size())
+	for i := Item(49); i >= 0; i-- {
+		require.Equal(t, i, h.Pop())
+	}
+}
+
+func TestUniqueRemoveIf(t *testing.T) {
+	url := func(p page) string { return p.url }
+	h, _ := NewUniqueMinHeap(2, url)
+	h.Heapify(page{"a", 1}, page{"b", 2}, page{"c", 3}, page{"d", 4})
+	require.Equal(t, 1, h.RemoveIf(func(p page) bool { return p.depth == 2 }))
+	require.False(t, h.Contains("b"))
+	require.Equal(t, []page{{"d", 4}}, h.Extract(func(p page) bool { return p.depth > 3 }))
+	require.Equal(t, 1, h.Retain(func(p page) bool { return p.url == "c" }))
+	require.NoError(t, h.Validate())
+	h.Push(page{"a", 5})
+	require.Equal(t, []page{{"c", 3}, {"a", 5}}, h.Slice())
+}
@@ -0,0 +1,366 @@
+package comparable
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidWeight is returned or panicked with when sizer returns negative weight
+var ErrInvalidWeight = errors.New("wrong value for weight")
+
+// baseWeightedPQ is priority queue bounded by total weight of items instead of their number
+type baseWeightedPQ[T Comparator[T]] struct {
+	baseHeap[T]
+	sizer  func(item T) int
+	limit  int
+	weight int
+}
+
+// WeightedMinPQ is minimum priority queue keeping items with min priority,
+// which total weight does not exceed the limit.
+// Zero value is empty unbounded priority queue with zero weights
+type WeightedMinPQ[T Comparator[T]] struct {
+	baseWeightedPQ[T]
+}
+
+// WeightedMaxPQ is maximum priority queue keeping items with max priority,
+// which total weight does not exceed the limit.
+// Zero value is empty unbounded priority queue with zero weights
+type WeightedMaxPQ[T Comparator[T]] struct {
+	baseWeightedPQ[T]
+}
+
+func newWeightedPQ[T Comparator[T]](
+	limit int,
+	sizer func(item T) int,
+	check func(item1 T, item2 T) bool,
+	opts []Option,
+) (baseWeightedPQ[T], error) {
+	if limit < 1 && limit != Unbounded {
+		return baseWeightedPQ[T]{}, fmt.Errorf("%w: %d. Cannot be less than 1", ErrInvalidSize, limit)
+	}
+	if sizer == nil {
+		return baseWeightedPQ[T]{}, errors.New("sizer function is required")
+	}
+	baseHeap, err := newHeap(2, check, true, opts)
+	if err != nil {
+		return baseWeightedPQ[T]{}, err
+	}
+	return baseWeightedPQ[T]{baseHeap: baseHeap, sizer: sizer, limit: limit}, nil
+}
+
+// NewWeightedMinPQ creates minimum priority queue with total weight limit.
+// Sizer returns weight of item, e.g. its size in bytes. It is required and should return
+// the same non-negative weight for the same item
+func NewWeightedMinPQ[T Comparator[T]](limit int, sizer func(item T) int, opts ...Option) (WeightedMinPQ[T], error) {
+	baseHeap, err := newWeightedPQ(limit, sizer, maxCheck[T], opts)
+	if err != nil {
+		return WeightedMinPQ[T]{}, err
+	}
+	return WeightedMinPQ[T]{baseHeap}, nil
+}
+
+// NewWeightedMaxPQ creates maximum priority queue with total weight limit.
+// Sizer returns weight of item, e.g. its size in bytes. It is required and should return
+// the same non-negative weight for the same item
+func NewWeightedMaxPQ[T Comparator[T]](limit int, sizer func(item T) int, opts ...Option) (WeightedMaxPQ[T], error) {
+	baseHeap, err := newWeightedPQ(limit, sizer, minCheck[T], opts)
+	if err != nil {
+		return WeightedMaxPQ[T]{}, err
+	}
+	return WeightedMaxPQ[T]{baseHeap}, nil
+}
+
+func (h *WeightedMinPQ[T]) base() *baseWeightedPQ[T] {
+	if h.check == nil {
+		h.init(maxCheck[T])
+	}
+	return &h.baseWeightedPQ
+}
+
+func (h *WeightedMaxPQ[T]) base() *baseWeightedPQ[T] {
+	if h.check == nil {
+		h.init(minCheck[T])
+	}
+	return &h.baseWeightedPQ
+}
+
+func (h *baseWeightedPQ[T]) weigh(item T) int {
+	if h.sizer == nil {
+		return 0
+	}
+	return h.sizer(item)
+}
+
+// accept returns ErrInvalidWeight if any of items weighs less than 0
+func (h *baseWeightedPQ[T]) accept(items ...T) error {
+	for _, item := range items {
+		if weight := h.weigh(item); weight < 0 {
+			return fmt.Errorf("%w: %d. Cannot be negative", ErrInvalidWeight, weight)
+		}
+	}
+	return nil
+}
+
+// mustAccept panics if priority queue rejects any of items
+func (h *baseWeightedPQ[T]) mustAccept(items ...T) {
+	if err := h.accept(items...); err != nil {
+		panic(err)
+	}
+}
+
+// overweight either priority queue exceeds limit after adding weight
+func (h *baseWeightedPQ[T]) overweight(weight int) bool {
+	return h.limit > 0 && h.weight+weight > h.limit
+}
+
+// push adds item and evicts items with the worst priority, including item itself, while total weight exceeds limit.
+// When one eviction is enough, the top item is replaced by item as in bounded priority queue push
+func (h *baseWeightedPQ[T]) push(item T) {
+	h.mustAccept(item)
+	weight := h.weigh(item)
+	if h.limit > 0 && weight > h.limit {
+		h.evicted(item)
+		return
+	}
+	for h.overweight(weight) && !h.empty() {
+		if h.check(item, h.at(0)) {
+			h.evicted(item)
+			return
+		}
+		top := h.at(0)
+		topWeight := h.weigh(top)
+		if !h.overweight(weight - topWeight) {
+			h.put(0, item)
+			h.down(0)
+			h.weight += weight - topWeight
+			h.evicted(top)
+			return
+		}
+		h.pop()
+		h.evicted(top)
+	}
+	h.baseHeap.push(item)
+	h.weight += weight
+}
+
+func (h *baseWeightedPQ[T]) pop() T {
+	item := h.baseHeap.pop()
+	h.weight -= h.weigh(item)
+	return item
+}
+
+func (h *baseWeightedPQ[T]) tryPop() (T, bool) {
+	if h.empty() {
+		var item T
+		return item, false
+	}
+	return h.pop(), true
+}
+
+// heapify initializes priority queue and evicts items with the worst priority while total weight exceeds limit
+func (h *baseWeightedPQ[T]) heapify(items ...T) {
+	h.mustAccept(items...)
+	h.baseHeap.heapify(items...)
+	h.weight = 0
+	for _, item := range items {
+		h.weight += h.weigh(item)
+	}
+	for h.overweight(0) {
+		h.evicted(h.pop())
+	}
+}
+
+func (h *baseWeightedPQ[T]) slice() []T {
+	res := make([]T, 0, h.len())
+	for !h.empty() {
+		res = append(res, h.pop())
+	}
+	return res
+}
+
+func (h *baseWeightedPQ[T]) orderedSlice() []T {
+	res := make([]T, h.len())
+	for i := h.len() - 1; i >= 0; i-- {
+		res[i] = h.pop()
+	}
+	return res
+}
+
+// Push adds item into priority queue and evicts items with max priority,
+// including item itself, while total weight exceeds limit. Panics if item weighs less than 0
+func (h *WeightedMinPQ[T]) Push(item T) {
+	h.base().push(item)
+}
+
+// Push adds item into priority queue and evicts items with min priority,
+// including item itself, while total weight exceeds limit. Panics if item weighs less than 0
+func (h *WeightedMaxPQ[T]) Push(item T) {
+	h.base().push(item)
+}
+
+// TryPush adds item into priority queue. Returns ErrInvalidWeight if priority queue rejects item
+func (h *WeightedMinPQ[T]) TryPush(item T) error {
+	if err := h.base().accept(item); err != nil {
+		return err
+	}
+	h.push(item)
+	return nil
+}
+
+// TryPush adds item into priority queue. Returns ErrInvalidWeight if priority queue rejects item
+func (h *WeightedMaxPQ[T]) TryPush(item T) error {
+	if err := h.base().accept(item); err != nil {
+		return err
+	}
+	h.push(item)
+	return nil
+}
+
+// OnEvict sets callback receiving items evicted from priority queue while total weight exceeds limit.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *WeightedMinPQ[T]) OnEvict(evict func(item T)) {
+	h.base().evict = evict
+}
+
+// OnEvict sets callback receiving items evicted from priority queue while total weight exceeds limit.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *WeightedMaxPQ[T]) OnEvict(evict func(item T)) {
+	h.base().evict = evict
+}
+
+// Pop returns and deletes max value
+func (h *WeightedMinPQ[T]) Pop() T {
+	return h.base().pop()
+}
+
+// Pop returns and deletes min value
+func (h *WeightedMaxPQ[T]) Pop() T {
+	return h.base().pop()
+}
+
+// TryPop returns and deletes max value. Returns false if priority queue is empty
+func (h *WeightedMinPQ[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes min value. Returns false if priority queue is empty
+func (h *WeightedMaxPQ[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// Pick returns max value
+func (h *WeightedMinPQ[T]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns min value
+func (h *WeightedMaxPQ[T]) Pick() T {
+	return h.pick()
+}
+
+// TryPick returns max value. Returns false if priority queue is empty
+func (h *WeightedMinPQ[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns min value. Returns false if priority queue is empty
+func (h *WeightedMaxPQ[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// Empty either priority queue is blank
+func (h *WeightedMinPQ[T]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either priority queue is blank
+func (h *WeightedMaxPQ[T]) Empty() bool {
+	return h.empty()
+}
+
+// Size returns priority queue size
+func (h *WeightedMinPQ[T]) Size() int {
+	return h.len()
+}
+
+// Size returns priority queue size
+func (h *WeightedMaxPQ[T]) Size() int {
+	return h.len()
+}
+
+// Weight returns total weight of items
+func (h *WeightedMinPQ[T]) Weight() int {
+	return h.weight
+}
+
+// Weight returns total weight of items
+func (h *WeightedMaxPQ[T]) Weight() int {
+	return h.weight
+}
+
+// Limit returns total weight limit or Unbounded
+func (h *WeightedMinPQ[T]) Limit() int {
+	if h.limit < 1 {
+		return Unbounded
+	}
+	return h.limit
+}
+
+// Limit returns total weight limit or Unbounded
+func (h *WeightedMaxPQ[T]) Limit() int {
+	if h.limit < 1 {
+		return Unbounded
+	}
+	return h.limit
+}
+
+// Heapify initializes priority queue. Panics if any of items weighs less than 0
+func (h *WeightedMinPQ[T]) Heapify(items ...T) {
+	h.base().heapify(items...)
+}
+
+// Heapify initializes priority queue. Panics if any of items weighs less than 0
+func (h *WeightedMaxPQ[T]) Heapify(items ...T) {
+	h.base().heapify(items...)
+}
+
+// TryHeapify initializes priority queue.
+// Returns ErrInvalidWeight and keeps priority queue unchanged if priority queue rejects any of items
+func (h *WeightedMinPQ[T]) TryHeapify(items ...T) error {
+	if err := h.base().accept(items...); err != nil {
+		return err
+	}
+	h.heapify(items...)
+	return nil
+}
+
+// TryHeapify initializes priority queue.
+// Returns ErrInvalidWeight and keeps priority queue unchanged if priority queue rejects any of items
+func (h *WeightedMaxPQ[T]) TryHeapify(items ...T) error {
+	if err := h.base().accept(items...); err != nil {
+		return err
+	}
+	h.heapify(items...)
+	return nil
+}
+
+// Slice returns priority queue slice
+func (h *WeightedMinPQ[T]) Slice() []T {
+	return h.base().slice()
+}
+
+// Slice returns priority queue slice
+func (h *WeightedMaxPQ[T]) Slice() []T {
+	return h.base().slice()
+}
+
+// OrderedSlice returns priority queue slice ordered by priority
+func (h *WeightedMinPQ[T]) OrderedSlice() []T {
+	return h.base().orderedSlice()
+}
+
+// OrderedSlice returns priority queue slice ordered by priority
+func (h *WeightedMaxPQ[T]) OrderedSlice() []T {
+	return h.base().orderedSlice()
+}
@@ -0,0 +1,50 @@
+package comparable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedPQ(t *testing.T) {
+	pq, err := NewWeightedMaxPQ(6, func(item Item) int { return int(item) })
+	require.NoError(t, err)
+	pq.Heapify(1, 2, 3, 4)
+	require.Equal(t, 1, pq.Size())
+	require.Equal(t, Item(4), pq.Pick())
+
+	pq.Push(1)
+	pq.Push(2)
+	require.Equal(t, 6, pq.Weight())
+	// evicts 2 and then 3 itself
+	pq.Push(3)
+	require.Equal(t, 4, pq.Weight())
+	pq.Push(5)
+	require.Equal(t, 5, pq.Weight())
+	pq.Push(1)
+	require.Equal(t, []Item{5, 1}, pq.OrderedSlice())
+}
+
+func TestWeightedPQInvalidSizer(t *testing.T) {
+	_, err := NewWeightedMinPQ[Item](10, nil)
+	require.Error(t, err)
+
+	pq, _ := NewWeightedMinPQ(10, func(item Item) int { return int(item) })
+	require.True(t, errors.Is(pq.TryPush(-1), ErrInvalidWeight))
+	require.True(t, errors.Is(pq.TryHeapify(1, -2), ErrInvalidWeight))
+	require.Panics(t, func() { pq.Push(-3) })
+	require.True(t, pq.Empty())
+}
+
+func TestWeightedPQRemoveIf(t *testing.T) {
+	pq, _ := NewWeightedMaxPQ(10, func(item Item) int { return int(item) })
+	pq.Heapify(1, 2, 3, 4)
+	require.Equal(t, 2, pq.RemoveIf(func(item Item) bool { return item > 2 }))
+	require.Equal(t, 3, pq.Weight())
+	require.Equal(t, []Item{2}, pq.Extract(func(item Item) bool { return item == 2 }))
+	require.Equal(t, 0, pq.Retain(func(item Item) bool { return true }))
+	require.Equal(t, 1, pq.Weight())
+	pq.Push(9)
+	require.Equal(t, 10, pq.Weight())
+}
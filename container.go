@@ -0,0 +1,68 @@
+package heap
+
+import (
+	stdheap "container/heap"
+	"errors"
+)
+
+// ErrEmpty is panic value on pop or pick from empty adapter
+var ErrEmpty = errors.New("empty container heap")
+
+// Adapter wraps container/heap.Interface implementation into Heap.
+// Items pushed into the underlying implementation must be of type T
+type Adapter[T any] struct {
+	h stdheap.Interface
+}
+
+// FromContainer creates Adapter over h. It initializes h, so h may be not a heap yet
+func FromContainer[T any](h stdheap.Interface) *Adapter[T] {
+	stdheap.Init(h)
+	return &Adapter[T]{h: h}
+}
+
+// Push adds item into heap
+func (a *Adapter[T]) Push(item T) {
+	stdheap.Push(a.h, item)
+}
+
+// Pop returns and deletes item with the top priority
+func (a *Adapter[T]) Pop() T {
+	if a.Empty() {
+		panic(ErrEmpty)
+	}
+	return stdheap.Pop(a.h).(T)
+}
+
+// Pick returns item with the top priority. container/heap.Interface has no access to items,
+// so the item is popped and pushed back
+func (a *Adapter[T]) Pick() T {
+	item := a.Pop()
+	a.Push(item)
+	return item
+}
+
+// Empty either heap is blank
+func (a *Adapter[T]) Empty() bool {
+	return a.h.Len() == 0
+}
+
+// Size returns heap size
+func (a *Adapter[T]) Size() int {
+	return a.h.Len()
+}
+
+// Heapify replaces items of the underlying implementation with items and initializes heap
+func (a *Adapter[T]) Heapify(items ...T) {
+	for a.h.Len() > 0 {
+		a.h.Pop()
+	}
+	for _, item := range items {
+		a.h.Push(item)
+	}
+	stdheap.Init(a.h)
+}
+
+// Unwrap returns the underlying container/heap.Interface implementation
+func (a *Adapter[T]) Unwrap() stdheap.Interface {
+	return a.h
+}
@@ -0,0 +1,54 @@
+package heap
+
+import (
+	stdheap "container/heap"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+func TestAdapter(t *testing.T) {
+	var _ Heap[int] = (*Adapter[int])(nil)
+
+	items := rand.Perm(100)
+	raw := intHeap(append([]int(nil), items[:50]...))
+	h := FromContainer[int](&raw)
+	for _, item := range items[50:] {
+		h.Push(item)
+	}
+	require.Equal(t, 100, h.Size())
+	require.Equal(t, 0, h.Pick())
+	require.Equal(t, 100, h.Size())
+
+	// underlying implementation is still usable with container/heap
+	stdheap.Push(h.Unwrap(), -1)
+	require.Equal(t, -1, h.Pop())
+
+	sort.Ints(items)
+	for _, expected := range items {
+		require.Equal(t, expected, h.Pop())
+	}
+	require.True(t, h.Empty())
+	require.PanicsWithValue(t, ErrEmpty, func() { h.Pop() })
+
+	h.Heapify(3, 1, 2)
+	require.Equal(t, 3, h.Size())
+	require.Equal(t, 1, h.Pop())
+	require.Equal(t, 2, h.Pop())
+	require.Equal(t, 3, h.Pop())
+}
@@ -0,0 +1,431 @@
+// Code generated by heapgen; DO NOT EDIT.
+
+package examples
+
+const taskFactor = 2
+
+func taskLess(a, b *Task) bool {
+	return a.Priority < b.Priority
+}
+
+func taskMinUp(items []*Task, idx int) {
+	item := items[idx]
+	for idx > 0 {
+		parent := (idx - 1) / taskFactor
+		if !taskLess(item, items[parent]) {
+			break
+		}
+		items[idx] = items[parent]
+		idx = parent
+	}
+	items[idx] = item
+}
+
+func taskMinDown(items []*Task, idx int) {
+	item := items[idx]
+	for {
+		first := idx*taskFactor + 1
+		if first >= len(items) {
+			break
+		}
+		child := first
+		for i := first + 1; i < first+taskFactor && i < len(items); i++ {
+			if taskLess(items[i], items[child]) {
+				child = i
+			}
+		}
+		if !taskLess(items[child], item) {
+			break
+		}
+		items[idx] = items[child]
+		idx = child
+	}
+	items[idx] = item
+}
+
+func taskMinHeapify(items []*Task) {
+	if len(items) == 0 {
+		return
+	}
+	for i := (len(items) - 1) / taskFactor; i >= 0; i-- {
+		taskMinDown(items, i)
+	}
+}
+
+func taskMinPop(items []*Task) (*Task, []*Task) {
+	if len(items) == 0 {
+		panic("empty heap")
+	}
+	last := len(items) - 1
+	item := items[0]
+	items[0] = items[last]
+	var zero *Task
+	items[last] = zero
+	items = items[:last]
+	if last > 0 {
+		taskMinDown(items, 0)
+	}
+	return item, items
+}
+
+func taskMaxUp(items []*Task, idx int) {
+	item := items[idx]
+	for idx > 0 {
+		parent := (idx - 1) / taskFactor
+		if !taskLess(items[parent], item) {
+			break
+		}
+		items[idx] = items[parent]
+		idx = parent
+	}
+	items[idx] = item
+}
+
+func taskMaxDown(items []*Task, idx int) {
+	item := items[idx]
+	for {
+		first := idx*taskFactor + 1
+		if first >= len(items) {
+			break
+		}
+		child := first
+		for i := first + 1; i < first+taskFactor && i < len(items); i++ {
+			if taskLess(items[child], items[i]) {
+				child = i
+			}
+		}
+		if !taskLess(item, items[child]) {
+			break
+		}
+		items[idx] = items[child]
+		idx = child
+	}
+	items[idx] = item
+}
+
+func taskMaxHeapify(items []*Task) {
+	if len(items) == 0 {
+		return
+	}
+	for i := (len(items) - 1) / taskFactor; i >= 0; i-- {
+		taskMaxDown(items, i)
+	}
+}
+
+func taskMaxPop(items []*Task) (*Task, []*Task) {
+	if len(items) == 0 {
+		panic("empty heap")
+	}
+	last := len(items) - 1
+	item := items[0]
+	items[0] = items[last]
+	var zero *Task
+	items[last] = zero
+	items = items[:last]
+	if last > 0 {
+		taskMaxDown(items, 0)
+	}
+	return item, items
+}
+
+// TaskMinHeap is heap that returns element with min priority
+type TaskMinHeap struct {
+	items []*Task
+}
+
+// Push adds item into heap
+func (h *TaskMinHeap) Push(item *Task) {
+	h.items = append(h.items, item)
+	taskMinUp(h.items, len(h.items)-1)
+}
+
+// Pop returns and deletes min value
+func (h *TaskMinHeap) Pop() *Task {
+	var item *Task
+	item, h.items = taskMinPop(h.items)
+	return item
+}
+
+// TryPop returns and deletes min value. Returns false if heap is empty
+func (h *TaskMinHeap) TryPop() (*Task, bool) {
+	if len(h.items) == 0 {
+		var item *Task
+		return item, false
+	}
+	return h.Pop(), true
+}
+
+// Pick returns min value
+func (h *TaskMinHeap) Pick() *Task {
+	if len(h.items) == 0 {
+		panic("empty heap")
+	}
+	return h.items[0]
+}
+
+// Empty either heap is blank
+func (h *TaskMinHeap) Empty() bool {
+	return len(h.items) == 0
+}
+
+// Size returns heap size
+func (h *TaskMinHeap) Size() int {
+	return len(h.items)
+}
+
+// Heapify initializes heap
+func (h *TaskMinHeap) Heapify(items ...*Task) {
+	h.items = items
+	taskMinHeapify(h.items)
+}
+
+// Slice returns heap slice
+func (h *TaskMinHeap) Slice() []*Task {
+	res := make([]*Task, 0, len(h.items))
+	for len(h.items) > 0 {
+		res = append(res, h.Pop())
+	}
+	return res
+}
+
+// TaskMaxHeap is heap that returns element with max priority
+type TaskMaxHeap struct {
+	items []*Task
+}
+
+// Push adds item into heap
+func (h *TaskMaxHeap) Push(item *Task) {
+	h.items = append(h.items, item)
+	taskMaxUp(h.items, len(h.items)-1)
+}
+
+// Pop returns and deletes max value
+func (h *TaskMaxHeap) Pop() *Task {
+	var item *Task
+	item, h.items = taskMaxPop(h.items)
+	return item
+}
+
+// TryPop returns and deletes max value. Returns false if heap is empty
+func (h *TaskMaxHeap) TryPop() (*Task, bool) {
+	if len(h.items) == 0 {
+		var item *Task
+		return item, false
+	}
+	return h.Pop(), true
+}
+
+// Pick returns max value
+func (h *TaskMaxHeap) Pick() *Task {
+	if len(h.items) == 0 {
+		panic("empty heap")
+	}
+	return h.items[0]
+}
+
+// Empty either heap is blank
+func (h *TaskMaxHeap) Empty() bool {
+	return len(h.items) == 0
+}
+
+// Size returns heap size
+func (h *TaskMaxHeap) Size() int {
+	return len(h.items)
+}
+
+// Heapify initializes heap
+func (h *TaskMaxHeap) Heapify(items ...*Task) {
+	h.items = items
+	taskMaxHeapify(h.items)
+}
+
+// Slice returns heap slice
+func (h *TaskMaxHeap) Slice() []*Task {
+	res := make([]*Task, 0, len(h.items))
+	for len(h.items) > 0 {
+		res = append(res, h.Pop())
+	}
+	return res
+}
+
+// TaskMinPQ is minimum bounded priority queue.
+// Zero value is empty unbounded priority queue
+type TaskMinPQ struct {
+	items []*Task
+	size  int
+}
+
+// NewTaskMinPQ creates minimum priority queue of size
+func NewTaskMinPQ(size int) TaskMinPQ {
+	return TaskMinPQ{size: size}
+}
+
+// Push adds item into priority queue
+func (h *TaskMinPQ) Push(item *Task) {
+	if h.size < 1 || len(h.items) < h.size {
+		h.items = append(h.items, item)
+		taskMaxUp(h.items, len(h.items)-1)
+		return
+	}
+	if taskLess(h.items[0], item) {
+		return
+	}
+	h.items[0] = item
+	taskMaxDown(h.items, 0)
+}
+
+// Pop returns and deletes max value
+func (h *TaskMinPQ) Pop() *Task {
+	var item *Task
+	item, h.items = taskMaxPop(h.items)
+	return item
+}
+
+// TryPop returns and deletes max value. Returns false if priority queue is empty
+func (h *TaskMinPQ) TryPop() (*Task, bool) {
+	if len(h.items) == 0 {
+		var item *Task
+		return item, false
+	}
+	return h.Pop(), true
+}
+
+// Pick returns max value
+func (h *TaskMinPQ) Pick() *Task {
+	if len(h.items) == 0 {
+		panic("empty priority queue")
+	}
+	return h.items[0]
+}
+
+// Empty either priority queue is blank
+func (h *TaskMinPQ) Empty() bool {
+	return len(h.items) == 0
+}
+
+// Size returns priority queue size
+func (h *TaskMinPQ) Size() int {
+	return len(h.items)
+}
+
+// Heapify initializes priority queue
+func (h *TaskMinPQ) Heapify(items ...*Task) {
+	size := len(items)
+	if h.size > 0 {
+		size = min(size, h.size)
+	}
+	h.items = items[:size]
+	taskMaxHeapify(h.items)
+	for _, item := range items[size:] {
+		h.Push(item)
+	}
+}
+
+// Slice returns priority queue slice
+func (h *TaskMinPQ) Slice() []*Task {
+	res := make([]*Task, 0, len(h.items))
+	for len(h.items) > 0 {
+		res = append(res, h.Pop())
+	}
+	return res
+}
+
+// OrderedSlice returns priority queue slice ordered by priority
+func (h *TaskMinPQ) OrderedSlice() []*Task {
+	res := make([]*Task, len(h.items))
+	for i := len(h.items) - 1; i >= 0; i-- {
+		res[i] = h.Pop()
+	}
+	return res
+}
+
+// TaskMaxPQ is maximum bounded priority queue.
+// Zero value is empty unbounded priority queue
+type TaskMaxPQ struct {
+	items []*Task
+	size  int
+}
+
+// NewTaskMaxPQ creates maximum priority queue of size
+func NewTaskMaxPQ(size int) TaskMaxPQ {
+	return TaskMaxPQ{size: size}
+}
+
+// Push adds item into priority queue
+func (h *TaskMaxPQ) Push(item *Task) {
+	if h.size < 1 || len(h.items) < h.size {
+		h.items = append(h.items, item)
+		taskMinUp(h.items, len(h.items)-1)
+		return
+	}
+	if taskLess(item, h.items[0]) {
+		return
+	}
+	h.items[0] = item
+	taskMinDown(h.items, 0)
+}
+
+// Pop returns and deletes min value
+func (h *TaskMaxPQ) Pop() *Task {
+	var item *Task
+	item, h.items = taskMinPop(h.items)
+	return item
+}
+
+// TryPop returns and deletes min value. Returns false if priority queue is empty
+func (h *TaskMaxPQ) TryPop() (*Task, bool) {
+	if len(h.items) == 0 {
+		var item *Task
+		return item, false
+	}
+	return h.Pop(), true
+}
+
+// Pick returns min value
+func (h *TaskMaxPQ) Pick() *Task {
+	if len(h.items) == 0 {
+		panic("empty priority queue")
+	}
+	return h.items[0]
+}
+
+// Empty either priority queue is blank
+func (h *TaskMaxPQ) Empty() bool {
+	return len(h.items) == 0
+}
+
+// Size returns priority queue size
+func (h *TaskMaxPQ) Size() int {
+	return len(h.items)
+}
+
+// Heapify initializes priority queue
+func (h *TaskMaxPQ) Heapify(items ...*Task) {
+	size := len(items)
+	if h.size > 0 {
+		size = min(size, h.size)
+	}
+	h.items = items[:size]
+	taskMinHeapify(h.items)
+	for _, item := range items[size:] {
+		h.Push(item)
+	}
+}
+
+// Slice returns priority queue slice
+func (h *TaskMaxPQ) Slice() []*Task {
+	res := make([]*Task, 0, len(h.items))
+	for len(h.items) > 0 {
+		res = append(res, h.Pop())
+	}
+	return res
+}
+
+// OrderedSlice returns priority queue slice ordered by priority
+func (h *TaskMaxPQ) OrderedSlice() []*Task {
+	res := make([]*Task, len(h.items))
+	for i := len(h.items) - 1; i >= 0; i-- {
+		res[i] = h.Pop()
+	}
+	return res
+}
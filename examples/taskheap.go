@@ -0,0 +1,21 @@
+package examples
+
+//go:generate go run ../cmd/heapgen -type *Task -less "a.Priority < b.Priority"
+
+// Task is item for heaps generated by heapgen
+type Task struct {
+	Name     string
+	Priority int
+}
+
+func taskheap() {
+	var h TaskMinHeap
+
+	h.Push(&Task{Name: "c", Priority: 3})
+	h.Push(&Task{Name: "a", Priority: 1})
+	h.Push(&Task{Name: "b", Priority: 2})
+
+	println(h.Pop().Name) // "a"
+	println(h.Pop().Name) // "b"
+	println(h.Pop().Name) // "c"
+}
@@ -0,0 +1,48 @@
+// Package heap contains interfaces implemented by heaps and priority queues
+// of ordered and comparable packages
+package heap
+
+// Unbounded is capacity of priority queue without size bound
+const Unbounded = -1
+
+// Heap is common interface of heaps and priority queues
+type Heap[T any] interface {
+	// Push adds item
+	Push(item T)
+	// Pop returns and deletes item with the top priority
+	Pop() T
+	// Pick returns item with the top priority
+	Pick() T
+	// Empty either heap is blank
+	Empty() bool
+	// Size returns number of items
+	Size() int
+	// Heapify initializes heap with items
+	Heapify(items ...T)
+}
+
+// BoundedQueue is priority queue keeping at most Capacity items with the best priority,
+// the others are evicted on Push and Heapify
+type BoundedQueue[T any] interface {
+	Heap[T]
+	// Capacity returns maximum number of items or Unbounded
+	Capacity() int
+	// SetCapacity changes maximum number of items and returns items evicted on shrink
+	SetCapacity(size int) ([]T, error)
+	// PushEvict adds item and returns evicted item with the worst priority if queue is full
+	PushEvict(item T) (T, bool)
+	// OrderedSlice returns items ordered from the best priority to the worst one
+	OrderedSlice() []T
+}
+
+// Inspector is implemented by heaps and priority queues exposing their layout for tests and debugging
+type Inspector[T any] interface {
+	// Validate checks heap property of all items. Returns *InvariantError for the first violating item
+	Validate() error
+	// Height returns number of heap levels
+	Height() int
+	// Factor returns number of children of every heap node
+	Factor() int
+	// Levels returns items grouped by heap levels starting from the top one
+	Levels() [][]T
+}
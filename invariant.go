@@ -0,0 +1,17 @@
+package heap
+
+import "fmt"
+
+// InvariantError is returned by Validate when item is ordered before its parent.
+// It usually means inconsistent Less or item mutated in place after insertion
+type InvariantError struct {
+	// Parent is level order index of parent item
+	Parent int
+	// Child is level order index of violating item
+	Child int
+}
+
+// Error returns description of violation
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("heap property violated: item %d is ordered before its parent %d", e.Child, e.Parent)
+}
@@ -0,0 +1,153 @@
+package ordered
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *intHeap) Push(x any) {
+	*h = append(*h, x.(int))
+}
+
+func (h *intHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+var benchSizes = []int{1_000, 100_000}
+
+func BenchmarkContainerHeap(b *testing.B) {
+	for _, size := range benchSizes {
+		items := rand.Perm(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := make(intHeap, 0, size)
+				for _, item := range items {
+					heap.Push(&h, item)
+				}
+				for h.Len() > 0 {
+					heap.Pop(&h)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMinHeap(b *testing.B) {
+	for _, factor := range []int{2, 3, 4, 8} {
+		for _, size := range benchSizes {
+			items := rand.Perm(size)
+			b.Run(fmt.Sprintf("factor=%d/size=%d", factor, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					h, _ := NewMinHeap[int](factor, WithCapacity(size))
+					for _, item := range items {
+						h.Push(item)
+					}
+					for !h.Empty() {
+						h.Pop()
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkContainerHeapInit(b *testing.B) {
+	for _, size := range benchSizes {
+		items := rand.Perm(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			h := make(intHeap, size)
+			for i := 0; i < b.N; i++ {
+				copy(h, items)
+				heap.Init(&h)
+			}
+		})
+	}
+}
+
+func BenchmarkMinHeapHeapify(b *testing.B) {
+	for _, factor := range []int{2, 4} {
+		for _, size := range benchSizes {
+			items := rand.Perm(size)
+			b.Run(fmt.Sprintf("factor=%d/size=%d", factor, size), func(b *testing.B) {
+				b.ReportAllocs()
+				h, _ := NewMinHeap[int](factor)
+				data := make([]int, size)
+				for i := 0; i < b.N; i++ {
+					copy(data, items)
+					h.Heapify(data...)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkMinHeapPushMany(b *testing.B) {
+	for _, size := range benchSizes {
+		items := rand.Perm(2 * size)
+		for _, batch := range []bool{false, true} {
+			b.Run(fmt.Sprintf("batch=%t/size=%d", batch, size), func(b *testing.B) {
+				b.ReportAllocs()
+				h, _ := NewMinHeap[int](2, WithCapacity(2*size))
+				data := make([]int, size)
+				for i := 0; i < b.N; i++ {
+					copy(data, items[:size])
+					h.Heapify(data...)
+					if batch {
+						h.PushMany(items[size:]...)
+						continue
+					}
+					for _, item := range items[size:] {
+						h.Push(item)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkLayoutPushPop(b *testing.B) {
+	type heap interface {
+		Push(int)
+		Pop() int
+	}
+	layouts := []struct {
+		name string
+		new  func() heap
+	}{
+		{"factor=2", func() heap { h, _ := NewMinHeap[int](2); return &h }},
+		{"factor=4", func() heap { h, _ := NewMinHeap[int](4); return &h }},
+		{"block=64", func() heap { h, _ := NewBlockMinHeap[int](64); return &h }},
+		{"block=512", func() heap { h, _ := NewBlockMinHeap[int](512); return &h }},
+	}
+	for _, size := range []int{1_000, 1_000_000, 10_000_000} {
+		items := rand.Perm(size)
+		for _, layout := range layouts {
+			b.Run(fmt.Sprintf("%s/size=%d", layout.name, size), func(b *testing.B) {
+				h := layout.new()
+				for _, item := range items {
+					h.Push(item)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					h.Push(h.Pop() + items[i%size])
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package ordered
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+const pageSize = 4096
+
+// ErrInvalidBlockSize is returned by constructors for block size which is not power of two or less than 4
+var ErrInvalidBlockSize = errors.New("wrong value for block size")
+
+// baseBlockHeap is binary heap with B-heap memory layout. Items are grouped into blocks,
+// every block keeps complete subtree of log2(blockSize) levels in blockSize slots (slot 0 is not used),
+// so sifting through these levels touches only one cache line or memory page.
+// Nodes are addressed both by logical index i (1-based, children are 2i and 2i+1)
+// and by their position in items.
+// Blocks form layers numbered as blockSize-ary tree. The last layer is partially filled,
+// so its blocks are packed to keep only 1<<bottom slots, the levels of layer in use.
+// Packed blocks are regrown when the next level is reached
+type baseBlockHeap[T constraints.Ordered] struct {
+	check     func(item1 T, item2 T) bool
+	items     []T
+	size      int
+	blockSize int
+	levels    int
+	rejectNaN bool
+	// full is number of block layers above the last one
+	full int
+	// first is number of the first block of the last layer
+	first int
+	// base is position of the first block of the last layer
+	base int
+	// bottom is number of levels kept in blocks of the last layer
+	bottom int
+}
+
+// BlockMinHeap is binary heap with B-heap layout that returns element with min priority.
+// Zero value is empty heap with page sized blocks
+type BlockMinHeap[T constraints.Ordered] struct {
+	baseBlockHeap[T]
+}
+
+// BlockMaxHeap is binary heap with B-heap layout that returns element with max priority.
+// Zero value is empty heap with page sized blocks
+type BlockMaxHeap[T constraints.Ordered] struct {
+	baseBlockHeap[T]
+}
+
+func newBlockHeap[T constraints.Ordered](
+	blockSize int,
+	check func(item1 T, item2 T) bool,
+	opts []Option,
+) (baseBlockHeap[T], error) {
+	if blockSize < 4 || bits.OnesCount(uint(blockSize)) != 1 {
+		return baseBlockHeap[T]{}, fmt.Errorf("%w: %d. Should be power of two not less than 4", ErrInvalidBlockSize, blockSize)
+	}
+	o := newOptions(opts)
+	if err := o.nanOnly(); err != nil {
+		return baseBlockHeap[T]{}, err
+	}
+	check, rejectNaN := nanPolicy(check, o.nan, false)
+	return baseBlockHeap[T]{
+		check:     check,
+		blockSize: blockSize,
+		levels:    bits.TrailingZeros(uint(blockSize)),
+		rejectNaN: rejectNaN,
+	}, nil
+}
+
+// NewBlockMinHeap heap constructor. Block size is number of items in block,
+// e.g. cache line or page size divided by item size. WithNaN is the only supported option
+func NewBlockMinHeap[T constraints.Ordered](blockSize int, opts ...Option) (BlockMinHeap[T], error) {
+	baseHeap, err := newBlockHeap(blockSize, minCheck[T], opts)
+	if err != nil {
+		return BlockMinHeap[T]{}, err
+	}
+	return BlockMinHeap[T]{baseHeap}, nil
+}
+
+// NewBlockMaxHeap heap constructor. Block size is number of items in block,
+// e.g. cache line or page size divided by item size. WithNaN is the only supported option
+func NewBlockMaxHeap[T constraints.Ordered](blockSize int, opts ...Option) (BlockMaxHeap[T], error) {
+	baseHeap, err := newBlockHeap(blockSize, maxCheck[T], opts)
+	if err != nil {
+		return BlockMaxHeap[T]{}, err
+	}
+	return BlockMaxHeap[T]{baseHeap}, nil
+}
+
+// pageBlockSize returns the largest power of two number of items fitting into memory page
+func pageBlockSize[T constraints.Ordered]() int {
+	var item T
+	return max(1<<(bits.Len(uint(pageSize/unsafe.Sizeof(item)))-1), 4)
+}
+
+// init makes zero value heap usable with page sized blocks
+func (h *baseBlockHeap[T]) init(check func(item1 T, item2 T) bool) {
+	h.check = check
+	h.blockSize = pageBlockSize[T]()
+	h.levels = bits.TrailingZeros(uint(h.blockSize))
+}
+
+func (h *BlockMinHeap[T]) base() *baseBlockHeap[T] {
+	if h.check == nil {
+		h.init(minCheck[T])
+	}
+	return &h.baseBlockHeap
+}
+
+func (h *BlockMaxHeap[T]) base() *baseBlockHeap[T] {
+	if h.check == nil {
+		h.init(maxCheck[T])
+	}
+	return &h.baseBlockHeap
+}
+
+// position returns items position of logical index i
+func (h *baseBlockHeap[T]) position(i int) int {
+	depth := bits.Len(uint(i)) - 1
+	block := 0
+	for depth >= h.levels {
+		depth -= h.levels
+		block = block*h.blockSize + (i>>depth)&(h.blockSize-1) + 1
+	}
+	local := 1<<depth | i&(1<<depth-1)
+	if block < h.first {
+		return block*h.blockSize + local
+	}
+	return h.base + (block-h.first)<<h.bottom + local
+}
+
+// child returns items position of the left child of node at position pos
+func (h *baseBlockHeap[T]) child(pos int) int {
+	if pos >= h.base {
+		return pos + (pos-h.base)&(1<<h.bottom-1)
+	}
+	local := pos & (h.blockSize - 1)
+	if half := h.blockSize >> 1; local >= half {
+		// leaf of the block, child is the root of one of the child blocks
+		block := pos - local + (local-half)<<1 + 1
+		if block >= h.first {
+			return h.base + (block-h.first)<<h.bottom + 1
+		}
+		return block<<h.levels + 1
+	}
+	return pos + local
+}
+
+// sibling returns items position of the right sibling of left child at position pos
+func (h *baseBlockHeap[T]) sibling(pos int) int {
+	if pos >= h.base {
+		if (pos-h.base)&(1<<h.bottom-1) == 1 {
+			return pos + 1<<h.bottom
+		}
+		return pos + 1
+	}
+	if pos&(h.blockSize-1) == 1 {
+		return pos + h.blockSize
+	}
+	return pos + 1
+}
+
+// parent returns items position of parent of node at position pos
+func (h *baseBlockHeap[T]) parent(pos int) int {
+	var block int
+	if pos >= h.base {
+		rel := pos - h.base
+		if local := rel & (1<<h.bottom - 1); local > 1 {
+			return pos - local + local>>1
+		}
+		block = h.first + rel>>h.bottom - 1
+	} else {
+		if local := pos & (h.blockSize - 1); local > 1 {
+			return pos - local + local>>1
+		}
+		block = pos>>h.levels - 1
+	}
+	// root of the block, parent is one of the leaves of the parent block
+	return block - block&(h.blockSize-1) + (h.blockSize+block&(h.blockSize-1))>>1
+}
+
+// layer returns block layer of logical index i and depth of i inside of it
+func (h *baseBlockHeap[T]) layer(i int) (int, int) {
+	depth := bits.Len(uint(i)) - 1
+	return depth / h.levels, depth % h.levels
+}
+
+// layout sets the last layer of blocks and number of levels kept in its blocks
+func (h *baseBlockHeap[T]) layout(full, bottom int) {
+	h.first = 0
+	for range full {
+		h.first = h.first*h.blockSize + 1
+	}
+	h.full, h.base, h.bottom = full, h.first*h.blockSize, bottom
+}
+
+// repack changes number of levels kept in blocks of the last layer moving their items
+func (h *baseBlockHeap[T]) repack(bottom int) {
+	blocks := (len(h.items) - h.base) >> h.bottom
+	from, to := 1<<h.bottom, 1<<bottom
+	if bottom > h.bottom {
+		length := h.base + blocks*to
+		h.items = slices.Grow(h.items, length-len(h.items))[:length]
+		// blocks are moved from the end not to overwrite the ones which are not moved yet
+		for block := blocks - 1; block >= 0; block-- {
+			src, dst := h.base+block*from, h.base+block*to
+			copy(h.items[dst:dst+from], h.items[src:src+from])
+			clear(h.items[dst+from : dst+to])
+		}
+	} else {
+		for block := range blocks {
+			src, dst := h.base+block*from, h.base+block*to
+			copy(h.items[dst:dst+to], h.items[src:src+to])
+		}
+		clear(h.items[h.base+blocks*to:])
+		h.items = h.items[:h.base+blocks*to]
+	}
+	h.bottom = bottom
+}
+
+// grow prepares layout for logical index i added after the last one
+func (h *baseBlockHeap[T]) grow(i int) {
+	layer, depth := h.layer(i)
+	if layer > h.full {
+		// the last layer is complete and has full blocks, new layer starts
+		h.layout(layer, 1)
+		return
+	}
+	if depth >= h.bottom {
+		h.repack(depth + 1)
+	}
+}
+
+// shrink releases layout of logical indexes after size. Blocks are repacked
+// when two levels become unused, so alternating push and pop does not repack them every time
+func (h *baseBlockHeap[T]) shrink() {
+	if h.size == 0 {
+		h.items = h.items[:0]
+		h.layout(0, 0)
+		return
+	}
+	layer, depth := h.layer(h.size)
+	if layer < h.full {
+		// the last layer is empty, blocks of the previous one are full
+		clear(h.items[h.base:])
+		h.items = h.items[:h.base]
+		h.layout(layer, h.levels)
+		return
+	}
+	if depth+3 <= h.bottom {
+		h.repack(h.bottom - 1)
+	}
+}
+
+func (h *baseBlockHeap[T]) up(i, pos int) {
+	item := h.items[pos]
+	for i > 1 {
+		parent := h.parent(pos)
+		if !h.check(item, h.items[parent]) {
+			break
+		}
+		h.items[pos] = h.items[parent]
+		i, pos = i/2, parent
+	}
+	h.items[pos] = item
+}
+
+func (h *baseBlockHeap[T]) down(i, pos int) {
+	item := h.items[pos]
+	for 2*i <= h.size {
+		i = 2 * i
+		child := h.child(pos)
+		if i < h.size {
+			if right := h.sibling(child); h.check(h.items[right], h.items[child]) {
+				i, child = i+1, right
+			}
+		}
+		if !h.check(h.items[child], item) {
+			break
+		}
+		h.items[pos] = h.items[child]
+		pos = child
+	}
+	h.items[pos] = item
+}
+
+// place grows items to hold block of the last layer with position pos and puts item there
+func (h *baseBlockHeap[T]) place(pos int, item T) {
+	if pos >= len(h.items) {
+		length := h.base + ((pos-h.base)>>h.bottom+1)<<h.bottom
+		h.items = slices.Grow(h.items, length-len(h.items))[:length]
+	}
+	h.items[pos] = item
+}
+
+func (h *baseBlockHeap[T]) push(item T) {
+	if err := rejectNaN(h.rejectNaN, item); err != nil {
+		panic(err)
+	}
+	h.size++
+	h.grow(h.size)
+	pos := h.position(h.size)
+	h.place(pos, item)
+	h.up(h.size, pos)
+}
+
+func (h *baseBlockHeap[T]) pop() T {
+	if h.empty() {
+		panic(ErrEmpty)
+	}
+	item := h.items[1]
+	last := h.position(h.size)
+	h.items[1] = h.items[last]
+	var zero T
+	h.items[last] = zero
+	h.size--
+	h.shrink()
+	if h.size > 0 {
+		h.down(1, 1)
+	}
+	return item
+}
+
+func (h *baseBlockHeap[T]) pick() T {
+	if h.empty() {
+		panic(ErrEmpty)
+	}
+	return h.items[1]
+}
+
+func (h *baseBlockHeap[T]) tryPick() (T, bool) {
+	if h.empty() {
+		var item T
+		return item, false
+	}
+	return h.items[1], true
+}
+
+func (h *baseBlockHeap[T]) tryPop() (T, bool) {
+	if h.empty() {
+		var item T
+		return item, false
+	}
+	return h.pop(), true
+}
+
+func (h *baseBlockHeap[T]) empty() bool {
+	return h.size == 0
+}
+
+func (h *baseBlockHeap[T]) heapify(items ...T) {
+	if err := rejectNaN(h.rejectNaN, items...); err != nil {
+		panic(err)
+	}
+	clear(h.items)
+	h.items = h.items[:0]
+	h.size = len(items)
+	if h.size == 0 {
+		h.layout(0, 0)
+		return
+	}
+	layer, depth := h.layer(h.size)
+	h.layout(layer, depth+1)
+	// the last allocated block is the one of the last root of layer blocks
+	start := 1 << (layer * h.levels)
+	last := h.position(min(h.size, 2*start-1))
+	length := h.base + ((last-h.base)>>h.bottom+1)<<h.bottom
+	h.items = slices.Grow(h.items, length)[:length]
+	for i, item := range items {
+		h.items[h.position(i+1)] = item
+	}
+	for i := h.size / 2; i >= 1; i-- {
+		h.down(i, h.position(i))
+	}
+}
+
+func (h *baseBlockHeap[T]) slice() []T {
+	res := make([]T, 0, h.size)
+	for !h.empty() {
+		res = append(res, h.pop())
+	}
+	return res
+}
+
+// Push adds item into heap
+func (h *BlockMinHeap[T]) Push(item T) {
+	h.base().push(item)
+}
+
+// Push adds item into heap
+func (h *BlockMaxHeap[T]) Push(item T) {
+	h.base().push(item)
+}
+
+// Pop returns and deletes min value
+func (h *BlockMinHeap[T]) Pop() T {
+	return h.base().pop()
+}
+
+// Pop returns and deletes max value
+func (h *BlockMaxHeap[T]) Pop() T {
+	return h.base().pop()
+}
+
+// TryPop returns and deletes min value. Returns false if heap is empty
+func (h *BlockMinHeap[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes max value. Returns false if heap is empty
+func (h *BlockMaxHeap[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// Pick returns min value
+func (h *BlockMinHeap[T]) Pick() T {
+	return h.pick()
+}
+
+// Pick returns max value
+func (h *BlockMaxHeap[T]) Pick() T {
+	return h.pick()
+}
+
+// TryPick returns min value. Returns false if heap is empty
+func (h *BlockMinHeap[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns max value. Returns false if heap is empty
+func (h *BlockMaxHeap[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// Empty either heap is blank
+func (h *BlockMinHeap[T]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either heap is blank
+func (h *BlockMaxHeap[T]) Empty() bool {
+	return h.empty()
+}
+
+// Size returns heap size
+func (h *BlockMinHeap[T]) Size() int {
+	return h.size
+}
+
+// Size returns heap size
+func (h *BlockMaxHeap[T]) Size() int {
+	return h.size
+}
+
+// Heapify initializes heap
+func (h *BlockMinHeap[T]) Heapify(items ...T) {
+	h.base().heapify(items...)
+}
+
+// Heapify initializes heap
+func (h *BlockMaxHeap[T]) Heapify(items ...T) {
+	h.base().heapify(items...)
+}
+
+// Slice returns heap slice
+func (h *BlockMinHeap[T]) Slice() []T {
+	return h.base().slice()
+}
+
+// Slice returns heap slice
+func (h *BlockMaxHeap[T]) Slice() []T {
+	return h.base().slice()
+}
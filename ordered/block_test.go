@@ -0,0 +1,130 @@
+package ordered
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireBlockLayout(t *testing.T, h *baseBlockHeap[int]) {
+	positions := map[int]bool{}
+	for i := 1; i <= h.size; i++ {
+		pos := h.position(i)
+		require.False(t, positions[pos], "block size %d, index %d", h.blockSize, i)
+		require.Less(t, pos, len(h.items))
+		positions[pos] = true
+		if 2*i <= h.size {
+			require.Equal(t, h.position(2*i), h.child(pos))
+		}
+		if 2*i+1 <= h.size {
+			require.Equal(t, h.position(2*i+1), h.sibling(h.child(pos)))
+		}
+		if i > 1 {
+			require.Equal(t, h.position(i/2), h.parent(pos))
+		}
+	}
+}
+
+func TestBlockHeapLayout(t *testing.T) {
+	for _, blockSize := range []int{4, 8, 64} {
+		h, _ := NewBlockMinHeap[int](blockSize)
+		for i := 1; i <= 5000; i++ {
+			h.Push(i)
+			if i%97 == 0 || i&(i-1) == 0 || (i+1)&i == 0 {
+				requireBlockLayout(t, &h.baseBlockHeap)
+			}
+		}
+		for i := 5000; i > 0; i-- {
+			require.Equal(t, 5001-i, h.Pop())
+			if i%89 == 0 || i&(i-1) == 0 || (i+1)&i == 0 {
+				requireBlockLayout(t, &h.baseBlockHeap)
+			}
+		}
+		h.Heapify(rand.Perm(3000)...)
+		requireBlockLayout(t, &h.baseBlockHeap)
+	}
+}
+
+func TestBlockHeapMemory(t *testing.T) {
+	for _, blockSize := range []int{4, 64, 512} {
+		h, _ := NewBlockMinHeap[int](blockSize)
+		for i := 1; i <= 300000; i++ {
+			h.Push(i)
+			if i > 16 {
+				require.LessOrEqual(t, float64(len(h.items))/float64(h.size), 3.0, "block size %d, size %d", blockSize, i)
+			}
+		}
+		for i := 300000; i > 16; i-- {
+			h.Pop()
+			require.LessOrEqual(t, float64(len(h.items))/float64(h.size), 6.0, "block size %d, size %d", blockSize, i)
+		}
+		h.Heapify(rand.Perm(1023)...)
+		require.LessOrEqual(t, float64(len(h.items))/float64(h.size), 3.0)
+	}
+}
+
+func TestBlockHeapPopOrder(t *testing.T) {
+	for _, blockSize := range []int{4, 8, 64} {
+		items := rand.Perm(3000)
+		minH, _ := NewBlockMinHeap[int](blockSize)
+		maxH, _ := NewBlockMaxHeap[int](blockSize)
+		for _, item := range items {
+			minH.Push(item)
+		}
+		maxH.Heapify(items...)
+		require.Equal(t, len(items), minH.Size())
+		require.Equal(t, len(items), maxH.Size())
+
+		sort.Ints(items)
+		require.Equal(t, items, minH.Slice(), "block size %d", blockSize)
+		sort.Sort(sort.Reverse(sort.IntSlice(items)))
+		require.Equal(t, items, maxH.Slice(), "block size %d", blockSize)
+		require.True(t, minH.Empty())
+	}
+}
+
+func TestBlockHeapZeroValue(t *testing.T) {
+	var h BlockMinHeap[string]
+	_, ok := h.TryPop()
+	require.False(t, ok)
+	h.Push("b")
+	h.Push("a")
+	h.Push("c")
+	item, ok := h.TryPick()
+	require.True(t, ok)
+	require.Equal(t, "a", item)
+	require.Equal(t, 256, h.blockSize)
+	require.Equal(t, []string{"a", "b", "c"}, h.Slice())
+	require.PanicsWithValue(t, ErrEmpty, func() { h.Pop() })
+}
+
+func TestBlockHeapWrongBlockSize(t *testing.T) {
+	for _, blockSize := range []int{0, 2, 6, 100} {
+		_, err := NewBlockMinHeap[int](blockSize)
+		require.True(t, errors.Is(err, ErrInvalidBlockSize))
+	}
+}
+
+func TestBlockHeapRemoveIf(t *testing.T) {
+	h, _ := NewBlockMinHeap[int](4)
+	h.Heapify(rand.Perm(300)...)
+	require.Equal(t, 30, h.RemoveIf(func(item int) bool { return item%10 == 0 }))
+	require.Equal(t, 120, h.Retain(func(item int) bool { return item%2 == 1 }))
+	extracted := h.Extract(func(item int) bool { return item < 10 })
+	sort.Ints(extracted)
+	require.Equal(t, []int{1, 3, 5, 7, 9}, extracted)
+	require.NoError(t, h.Validate())
+	requireBlockLayout(t, &h.baseBlockHeap)
+	require.Equal(t, 145, h.Size())
+	require.Equal(t, 11, h.Pop())
+
+	maxH, _ := NewBlockMaxHeap[int](8)
+	maxH.Heapify(1, 2, 3)
+	require.Equal(t, 3, maxH.RemoveIf(func(int) bool { return true }))
+	require.True(t, maxH.Empty())
+	maxH.Push(4)
+	require.Equal(t, 4, maxH.Pop())
+}
@@ -0,0 +1,176 @@
+package ordered
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MinHeapBy is heap that returns element with min key. Key of every element is computed once
+// on insert and kept alongside the element, so sifts compare cached keys.
+// Zero value is not usable, use NewMinHeapBy
+type MinHeapBy[T any, K constraints.Ordered] struct {
+	keyed KeyedMinHeap[K, T]
+	key   func(T) K
+}
+
+// MaxHeapBy is heap that returns element with max key. Key of every element is computed once
+// on insert and kept alongside the element, so sifts compare cached keys.
+// Zero value is not usable, use NewMaxHeapBy
+type MaxHeapBy[T any, K constraints.Ordered] struct {
+	keyed KeyedMaxHeap[K, T]
+	key   func(T) K
+}
+
+// NewMinHeapBy heap constructor. Key returns priority of element and is required.
+// WithNaN is the only supported option, it applies to keys
+func NewMinHeapBy[T any, K constraints.Ordered](factor int, key func(T) K, opts ...Option) (MinHeapBy[T, K], error) {
+	if key == nil {
+		return MinHeapBy[T, K]{}, errors.New("key function is required")
+	}
+	keyed, err := NewKeyedMinHeap[K, T](factor, opts...)
+	if err != nil {
+		return MinHeapBy[T, K]{}, err
+	}
+	return MinHeapBy[T, K]{keyed: keyed, key: key}, nil
+}
+
+// NewMaxHeapBy heap constructor. Key returns priority of element and is required.
+// WithNaN is the only supported option, it applies to keys
+func NewMaxHeapBy[T any, K constraints.Ordered](factor int, key func(T) K, opts ...Option) (MaxHeapBy[T, K], error) {
+	if key == nil {
+		return MaxHeapBy[T, K]{}, errors.New("key function is required")
+	}
+	keyed, err := NewKeyedMaxHeap[K, T](factor, opts...)
+	if err != nil {
+		return MaxHeapBy[T, K]{}, err
+	}
+	return MaxHeapBy[T, K]{keyed: keyed, key: key}, nil
+}
+
+// keys returns keys of items
+func keys[T any, K constraints.Ordered](items []T, key func(T) K) []K {
+	res := make([]K, len(items))
+	for i, item := range items {
+		res[i] = key(item)
+	}
+	return res
+}
+
+// Push adds item into heap
+func (h *MinHeapBy[T, K]) Push(item T) {
+	h.keyed.Push(h.key(item), item)
+}
+
+// Push adds item into heap
+func (h *MaxHeapBy[T, K]) Push(item T) {
+	h.keyed.Push(h.key(item), item)
+}
+
+// Pop returns and deletes item with min key
+func (h *MinHeapBy[T, K]) Pop() T {
+	_, item := h.keyed.Pop()
+	return item
+}
+
+// Pop returns and deletes item with max key
+func (h *MaxHeapBy[T, K]) Pop() T {
+	_, item := h.keyed.Pop()
+	return item
+}
+
+// TryPop returns and deletes item with min key. Returns false if heap is empty
+func (h *MinHeapBy[T, K]) TryPop() (T, bool) {
+	_, item, ok := h.keyed.TryPop()
+	return item, ok
+}
+
+// TryPop returns and deletes item with max key. Returns false if heap is empty
+func (h *MaxHeapBy[T, K]) TryPop() (T, bool) {
+	_, item, ok := h.keyed.TryPop()
+	return item, ok
+}
+
+// Pick returns item with min key
+func (h *MinHeapBy[T, K]) Pick() T {
+	_, item := h.keyed.Pick()
+	return item
+}
+
+// Pick returns item with max key
+func (h *MaxHeapBy[T, K]) Pick() T {
+	_, item := h.keyed.Pick()
+	return item
+}
+
+// TryPick returns item with min key. Returns false if heap is empty
+func (h *MinHeapBy[T, K]) TryPick() (T, bool) {
+	_, item, ok := h.keyed.TryPick()
+	return item, ok
+}
+
+// TryPick returns item with max key. Returns false if heap is empty
+func (h *MaxHeapBy[T, K]) TryPick() (T, bool) {
+	_, item, ok := h.keyed.TryPick()
+	return item, ok
+}
+
+// PickKey returns cached min key
+func (h *MinHeapBy[T, K]) PickKey() K {
+	key, _ := h.keyed.Pick()
+	return key
+}
+
+// PickKey returns cached max key
+func (h *MaxHeapBy[T, K]) PickKey() K {
+	key, _ := h.keyed.Pick()
+	return key
+}
+
+// Empty either heap is blank
+func (h *MinHeapBy[T, K]) Empty() bool {
+	return h.keyed.Empty()
+}
+
+// Empty either heap is blank
+func (h *MaxHeapBy[T, K]) Empty() bool {
+	return h.keyed.Empty()
+}
+
+// Size returns heap size
+func (h *MinHeapBy[T, K]) Size() int {
+	return h.keyed.Size()
+}
+
+// Size returns heap size
+func (h *MaxHeapBy[T, K]) Size() int {
+	return h.keyed.Size()
+}
+
+// Heapify initializes heap
+func (h *MinHeapBy[T, K]) Heapify(items ...T) {
+	h.keyed.Heapify(keys(items, h.key), items)
+}
+
+// Heapify initializes heap
+func (h *MaxHeapBy[T, K]) Heapify(items ...T) {
+	h.keyed.Heapify(keys(items, h.key), items)
+}
+
+// Slice returns heap slice
+func (h *MinHeapBy[T, K]) Slice() []T {
+	res := make([]T, 0, h.Size())
+	for !h.Empty() {
+		res = append(res, h.Pop())
+	}
+	return res
+}
+
+// Slice returns heap slice
+func (h *MaxHeapBy[T, K]) Slice() []T {
+	res := make([]T, 0, h.Size())
+	for !h.Empty() {
+		res = append(res, h.Pop())
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package ordered
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeapByCachesKeys(t *testing.T) {
+	calls := 0
+	key := func(item string) int {
+		calls++
+		key, _ := strconv.Atoi(item)
+		return key
+	}
+	perm := rand.Perm(1000)
+	items := make([]string, len(perm))
+	for i, item := range perm {
+		items[i] = strconv.Itoa(item)
+	}
+
+	h, err := NewMinHeapBy(3, key)
+	require.NoError(t, err)
+	for _, item := range items[:500] {
+		h.Push(item)
+	}
+	maxH, _ := NewMaxHeapBy(2, key)
+	maxH.Heapify(items[500:]...)
+	require.Equal(t, len(items), calls)
+
+	res := h.Slice()
+	require.Len(t, res, 500)
+	require.True(t, sort.SliceIsSorted(res, func(i, j int) bool { return key(res[i]) < key(res[j]) }))
+	calls = 0
+	pickKey, pick := maxH.PickKey(), maxH.Pick()
+	res = maxH.Slice()
+	require.Zero(t, calls)
+	require.Equal(t, pick, res[0])
+	require.Equal(t, pickKey, key(pick))
+	require.True(t, sort.SliceIsSorted(res, func(i, j int) bool { return key(res[i]) > key(res[j]) }))
+
+	_, ok := maxH.TryPop()
+	require.False(t, ok)
+	_, err = NewMinHeapBy(1, key)
+	require.True(t, errors.Is(err, ErrInvalidFactor))
+	_, err = NewMinHeapBy[string, int](2, nil)
+	require.EqualError(t, err, "key function is required")
+	_, err = NewMaxHeapBy[string, int](2, nil)
+	require.EqualError(t, err, "key function is required")
+}
+
+func TestHeapByRemoveIf(t *testing.T) {
+	key := func(item string) int {
+		key, _ := strconv.Atoi(item)
+		return key
+	}
+	items := make([]string, 100)
+	for i, item := range rand.Perm(100) {
+		items[i] = strconv.Itoa(item)
+	}
+	h, _ := NewMinHeapBy(2, key)
+	h.Heapify(items...)
+	require.Equal(t, 10, h.RemoveIf(func(item string) bool { return len(item) == 1 }))
+	require.Equal(t, 45, h.Retain(func(item string) bool { return key(item)%2 == 0 }))
+	extracted := h.Extract(func(item string) bool { return key(item) < 20 })
+	sort.Strings(extracted)
+	require.Equal(t, []string{"10", "12", "14", "16", "18"}, extracted)
+	require.NoError(t, h.Validate())
+	require.Equal(t, "20", h.Pop())
+
+	maxH, _ := NewMaxHeapBy(4, key)
+	maxH.Heapify(items...)
+	require.Equal(t, 50, maxH.RemoveIf(func(item string) bool { return key(item) >= 50 }))
+	require.NoError(t, maxH.Validate())
+	require.Equal(t, "49", maxH.Pick())
+}
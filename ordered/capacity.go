@@ -0,0 +1,113 @@
+package ordered
+
+import "slices"
+
+// reserve grows items capacity to hold at least n more items. Storage manages its capacity itself
+func (h *baseHeap[T]) reserve(n int) {
+	if n > 0 && h.storage == nil {
+		h.items = slices.Grow(h.items, n)
+	}
+}
+
+// clear deletes all items keeping capacity. Items are zeroed to be garbage collected
+func (h *baseHeap[T]) clear() {
+	h.save()
+	h.drop(0)
+}
+
+// shrink releases items capacity exceeding items length. Storage manages its capacity itself
+func (h *baseHeap[T]) shrink() {
+	if h.storage == nil && cap(h.items) > len(h.items) {
+		items := make([]T, len(h.items))
+		copy(items, h.items)
+		h.items = items
+	}
+}
+
+// capacity returns items capacity. Capacity of storage is its length
+func (h *baseHeap[T]) capacity() int {
+	if h.storage != nil {
+		return h.storage.Len()
+	}
+	return cap(h.items)
+}
+
+// Reserve grows heap capacity to hold at least n more items without reallocation
+func (h *MinHeap[T]) Reserve(n int) {
+	h.reserve(n)
+}
+
+// Reserve grows heap capacity to hold at least n more items without reallocation
+func (h *MaxHeap[T]) Reserve(n int) {
+	h.reserve(n)
+}
+
+// Reserve grows priority queue capacity to hold at least n more items without reallocation
+func (h *MinPQ[T]) Reserve(n int) {
+	h.reserve(n)
+}
+
+// Reserve grows priority queue capacity to hold at least n more items without reallocation
+func (h *MaxPQ[T]) Reserve(n int) {
+	h.reserve(n)
+}
+
+// Clear deletes all items from heap keeping its capacity
+func (h *MinHeap[T]) Clear() {
+	h.clear()
+}
+
+// Clear deletes all items from heap keeping its capacity
+func (h *MaxHeap[T]) Clear() {
+	h.clear()
+}
+
+// Clear deletes all items from priority queue keeping its capacity
+func (h *MinPQ[T]) Clear() {
+	h.clear()
+}
+
+// Clear deletes all items from priority queue keeping its capacity
+func (h *MaxPQ[T]) Clear() {
+	h.clear()
+}
+
+// Shrink releases heap capacity exceeding its size
+func (h *MinHeap[T]) Shrink() {
+	h.shrink()
+}
+
+// Shrink releases heap capacity exceeding its size
+func (h *MaxHeap[T]) Shrink() {
+	h.shrink()
+}
+
+// Shrink releases priority queue capacity exceeding its size
+func (h *MinPQ[T]) Shrink() {
+	h.shrink()
+}
+
+// Shrink releases priority queue capacity exceeding its size
+func (h *MaxPQ[T]) Shrink() {
+	h.shrink()
+}
+
+// Cap returns heap capacity
+func (h *MinHeap[T]) Cap() int {
+	return h.capacity()
+}
+
+// Cap returns heap capacity
+func (h *MaxHeap[T]) Cap() int {
+	return h.capacity()
+}
+
+// Cap returns priority queue capacity
+func (h *MinPQ[T]) Cap() int {
+	return h.capacity()
+}
+
+// Cap returns priority queue capacity
+func (h *MaxPQ[T]) Cap() int {
+	return h.capacity()
+}
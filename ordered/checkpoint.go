@@ -0,0 +1,299 @@
+package ordered
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// ErrInvalidCheckpoint is returned on rollback or commit of checkpoint
+// which was not taken on heap or is already released
+var ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+
+// Checkpoint is token of heap state to be restored with Rollback
+type Checkpoint uint64
+
+// checkpoints generates checkpoint tokens unique across heaps
+var checkpoints atomic.Uint64
+
+type undoOp uint8
+
+const (
+	// undoSet puts back item overwritten at idx
+	undoSet undoOp = iota
+	// undoResize restores items length idx
+	undoResize
+	// undoMove moves items back along the path of sift from idx to to
+	undoMove
+	// undoSave restores items saved before heap was rebuilt
+	undoSave
+	// undoBound restores priority queue size bound idx
+	undoBound
+)
+
+// undoEntry is record of one change of heap items
+type undoEntry[T any] struct {
+	op    undoOp
+	idx   int
+	to    int
+	item  T
+	items []T
+}
+
+// mark is open checkpoint with undo log length at the moment it was taken
+type mark struct {
+	id  Checkpoint
+	pos int
+}
+
+// undoLog keeps changes of heap items made since the first open checkpoint.
+// Sifts are logged with their start and end indexes only, items on the path are restored by moving them back
+type undoLog[T any] struct {
+	entries []undoEntry[T]
+	marks   []mark
+}
+
+// set puts item at idx logging overwritten item
+func (h *baseHeap[T]) set(idx int, item T) {
+	if h.undo != nil {
+		h.undo.entries = append(h.undo.entries, undoEntry[T]{op: undoSet, idx: idx, item: h.at(idx)})
+	}
+	if h.storage != nil {
+		h.storage.Set(idx, item)
+		return
+	}
+	h.items[idx] = item
+}
+
+// appendItem adds item to the end of items logging previous length
+func (h *baseHeap[T]) appendItem(item T) {
+	h.resized()
+	if h.storage != nil {
+		h.storage.Append(item)
+		return
+	}
+	h.items = append(h.items, item)
+}
+
+// truncate deletes items starting from n logging deleted items and previous length.
+// Deleted items are zeroed to be garbage collected
+func (h *baseHeap[T]) truncate(n int) {
+	if h.undo != nil {
+		for i := n; i < h.len(); i++ {
+			h.undo.entries = append(h.undo.entries, undoEntry[T]{op: undoSet, idx: i, item: h.at(i)})
+		}
+		h.resized()
+	}
+	if h.storage != nil {
+		h.storage.Truncate(n)
+		return
+	}
+	var zero T
+	for i := n; i < len(h.items); i++ {
+		h.items[i] = zero
+	}
+	h.items = h.items[:n]
+}
+
+// resized logs items length before resize
+func (h *baseHeap[T]) resized() {
+	if h.undo != nil {
+		h.undo.entries = append(h.undo.entries, undoEntry[T]{op: undoResize, idx: h.len()})
+	}
+}
+
+// moved logs sift of item from index from to index to
+func (h *baseHeap[T]) moved(from, to int) {
+	if h.undo != nil && from != to {
+		h.undo.entries = append(h.undo.entries, undoEntry[T]{op: undoMove, idx: from, to: to})
+	}
+}
+
+// bounded logs priority queue size bound before it is changed
+func (h *baseHeap[T]) bounded(size int) {
+	if h.undo != nil {
+		h.undo.entries = append(h.undo.entries, undoEntry[T]{op: undoBound, idx: size})
+	}
+}
+
+// save logs copy of items before heap is rebuilt at once
+func (h *baseHeap[T]) save() {
+	if h.undo != nil {
+		h.undo.entries = append(h.undo.entries, undoEntry[T]{op: undoSave, items: h.snapshot()})
+	}
+}
+
+// moveBack reverts sift of item from index from to index to
+func (h *baseHeap[T]) moveBack(from, to int) {
+	if from < to {
+		// sift down, items on the path were moved up
+		item := h.at(to)
+		for idx := to; idx != from; {
+			parent := h.parent(idx)
+			h.put(idx, h.at(parent))
+			idx = parent
+		}
+		h.put(from, item)
+		return
+	}
+	// sift up, items on the path were moved down
+	item := h.at(from)
+	h.put(from, h.at(to))
+	for idx := h.parent(from); idx != to; idx = h.parent(idx) {
+		next := h.at(idx)
+		h.put(idx, item)
+		item = next
+	}
+	h.put(to, item)
+}
+
+// revert undoes change of items or priority queue size bound recorded by entry.
+// Size is nil for heaps without size bound
+func (h *baseHeap[T]) revert(entry undoEntry[T], size *int) {
+	switch entry.op {
+	case undoSet:
+		h.put(entry.idx, entry.item)
+	case undoResize:
+		switch {
+		case entry.idx < h.len():
+			h.drop(entry.idx)
+		case h.storage != nil:
+			var zero T
+			for h.storage.Len() < entry.idx {
+				h.storage.Append(zero)
+			}
+		default:
+			h.items = slices.Grow(h.items, entry.idx-len(h.items))[:entry.idx]
+		}
+	case undoMove:
+		h.moveBack(entry.idx, entry.to)
+	case undoSave:
+		h.load(entry.items)
+	case undoBound:
+		if size != nil {
+			*size = entry.idx
+		}
+	}
+}
+
+func (h *baseHeap[T]) checkpoint() Checkpoint {
+	if h.undo == nil {
+		h.undo = &undoLog[T]{}
+	}
+	id := Checkpoint(checkpoints.Add(1))
+	h.undo.marks = append(h.undo.marks, mark{id: id, pos: len(h.undo.entries)})
+	return id
+}
+
+// find returns index of open checkpoint id
+func (h *baseHeap[T]) find(id Checkpoint) (int, error) {
+	if h.undo != nil {
+		for i, m := range h.undo.marks {
+			if m.id == id {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %d", ErrInvalidCheckpoint, id)
+}
+
+// release closes checkpoint with index i and checkpoints taken after it.
+// Logging stops when there are no open checkpoints
+func (h *baseHeap[T]) release(i int) {
+	h.undo.marks = h.undo.marks[:i]
+	if i == 0 {
+		h.undo = nil
+	}
+}
+
+func (h *baseHeap[T]) rollback(id Checkpoint, size *int) error {
+	i, err := h.find(id)
+	if err != nil {
+		return err
+	}
+	pos := h.undo.marks[i].pos
+	entries := h.undo.entries
+	for j := len(entries) - 1; j >= pos; j-- {
+		h.revert(entries[j], size)
+	}
+	clear(entries[pos:])
+	h.undo.entries = entries[:pos]
+	h.release(i)
+	return nil
+}
+
+func (h *baseHeap[T]) commit(id Checkpoint) error {
+	i, err := h.find(id)
+	if err != nil {
+		return err
+	}
+	h.release(i)
+	return nil
+}
+
+// Checkpoint starts logging changes of heap and returns token of its current state.
+// Checkpoints can be nested
+func (h *MinHeap[T]) Checkpoint() Checkpoint {
+	return h.base().checkpoint()
+}
+
+// Checkpoint starts logging changes of heap and returns token of its current state.
+// Checkpoints can be nested
+func (h *MaxHeap[T]) Checkpoint() Checkpoint {
+	return h.base().checkpoint()
+}
+
+// Checkpoint starts logging changes of priority queue and returns token of its current state.
+// Checkpoints can be nested
+func (h *MinPQ[T]) Checkpoint() Checkpoint {
+	return h.base().checkpoint()
+}
+
+// Checkpoint starts logging changes of priority queue and returns token of its current state.
+// Checkpoints can be nested
+func (h *MaxPQ[T]) Checkpoint() Checkpoint {
+	return h.base().checkpoint()
+}
+
+// Rollback restores heap state of checkpoint and releases it with checkpoints taken after it
+func (h *MinHeap[T]) Rollback(id Checkpoint) error {
+	return h.rollback(id, nil)
+}
+
+// Rollback restores heap state of checkpoint and releases it with checkpoints taken after it
+func (h *MaxHeap[T]) Rollback(id Checkpoint) error {
+	return h.rollback(id, nil)
+}
+
+// Rollback restores priority queue items and size bound of checkpoint and releases it with checkpoints
+// taken after it. Evicted items are restored, but eviction callback calls are not reverted
+func (h *MinPQ[T]) Rollback(id Checkpoint) error {
+	return h.rollback(id, &h.size)
+}
+
+// Rollback restores priority queue items and size bound of checkpoint and releases it with checkpoints
+// taken after it. Evicted items are restored, but eviction callback calls are not reverted
+func (h *MaxPQ[T]) Rollback(id Checkpoint) error {
+	return h.rollback(id, &h.size)
+}
+
+// Commit keeps heap changes made since checkpoint and releases it with checkpoints taken after it
+func (h *MinHeap[T]) Commit(id Checkpoint) error {
+	return h.commit(id)
+}
+
+// Commit keeps heap changes made since checkpoint and releases it with checkpoints taken after it
+func (h *MaxHeap[T]) Commit(id Checkpoint) error {
+	return h.commit(id)
+}
+
+// Commit keeps priority queue changes made since checkpoint and releases it with checkpoints taken after it
+func (h *MinPQ[T]) Commit(id Checkpoint) error {
+	return h.commit(id)
+}
+
+// Commit keeps priority queue changes made since checkpoint and releases it with checkpoints taken after it
+func (h *MaxPQ[T]) Commit(id Checkpoint) error {
+	return h.commit(id)
+}
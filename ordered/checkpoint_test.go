@@ -0,0 +1,129 @@
+package ordered
+
+import (
+	"container/heap"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mutate applies random heap operation
+func mutate(t *testing.T, h *MinHeap[int], rnd *rand.Rand) {
+	switch rnd.Intn(8) {
+	case 0, 1:
+		h.Push(rnd.Intn(100))
+	case 2:
+		h.TryPop()
+	case 3:
+		h.PushPop(rnd.Intn(100))
+	case 4:
+		if !h.Empty() {
+			h.Replace(rnd.Intn(100))
+		}
+	case 5:
+		items := make([]int, rnd.Intn(20))
+		for i := range items {
+			items[i] = rnd.Intn(100)
+		}
+		h.PushMany(items...)
+	case 6:
+		h.RemoveIf(func(item int) bool { return item%7 == 0 })
+	case 7:
+		c, err := h.Container()
+		if err != nil {
+			h.Clear()
+			return
+		}
+		heap.Push(c, rnd.Intn(100))
+		if c.Len() > 1 {
+			heap.Remove(c, rnd.Intn(c.Len()))
+		}
+	}
+	require.NoError(t, h.validate())
+}
+
+func TestCheckpointRollback(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, factor := range []int{2, 3, 4} {
+		for _, opts := range [][]Option{nil, {WithBottomUp()}} {
+			h, _ := NewMinHeap[int](factor, opts...)
+			h.Heapify(rnd.Perm(50)...)
+			for range 100 {
+				outer := append([]int{}, h.items...)
+				id := h.Checkpoint()
+				for range rnd.Intn(10) {
+					mutate(t, &h, rnd)
+				}
+				inner := append([]int{}, h.items...)
+				nested := h.Checkpoint()
+				for range rnd.Intn(10) {
+					mutate(t, &h, rnd)
+				}
+				require.NoError(t, h.Rollback(nested))
+				require.Equal(t, inner, append([]int{}, h.items...))
+				if rnd.Intn(2) == 0 {
+					require.NoError(t, h.Rollback(id))
+					require.Equal(t, outer, append([]int{}, h.items...))
+				} else {
+					require.NoError(t, h.Commit(id))
+				}
+				require.Nil(t, h.undo)
+			}
+		}
+	}
+}
+
+func TestCheckpointRelease(t *testing.T) {
+	var h MaxHeap[int]
+	h.Heapify(1, 2, 3)
+	id := h.Checkpoint()
+	nested := h.Checkpoint()
+	h.Pop()
+	require.NoError(t, h.Commit(nested))
+	h.Pop()
+	require.NoError(t, h.Rollback(id))
+	require.Equal(t, []int{3, 2, 1}, h.Slice())
+
+	require.True(t, errors.Is(h.Rollback(id), ErrInvalidCheckpoint))
+	require.True(t, errors.Is(h.Commit(nested), ErrInvalidCheckpoint))
+
+	id = h.Checkpoint()
+	nested = h.Checkpoint()
+	require.NoError(t, h.Commit(id))
+	require.True(t, errors.Is(h.Rollback(nested), ErrInvalidCheckpoint))
+	other, _ := NewMaxHeap[int](2)
+	require.True(t, errors.Is(other.Rollback(other.Checkpoint()+1), ErrInvalidCheckpoint))
+}
+
+func TestCheckpointPQ(t *testing.T) {
+	var evicted []int
+	pq, _ := NewMaxPQ[int](3)
+	pq.OnEvict(func(item int) { evicted = append(evicted, item) })
+	pq.Heapify(5, 1, 4)
+	id := pq.Checkpoint()
+	pq.Push(6)
+	pq.Push(7)
+	shrunk, err := pq.SetCapacity(1)
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 6}, shrunk)
+	require.Equal(t, []int{1, 4, 5, 6}, evicted)
+	require.NoError(t, pq.Rollback(id))
+	require.Equal(t, 3, pq.Capacity())
+	pq.Push(0)
+	require.Equal(t, []int{5, 4, 1}, pq.OrderedSlice())
+}
+
+func TestCheckpointRemoveIf(t *testing.T) {
+	var h MinHeap[int]
+	h.Heapify(rand.Perm(20)...)
+	id := h.Checkpoint()
+	require.Equal(t, 0, h.RemoveIf(func(item int) bool { return item > 100 }))
+	require.Empty(t, h.Extract(func(item int) bool { return item < 0 }))
+	require.Empty(t, h.undo.entries)
+	require.Equal(t, 10, h.RemoveIf(func(item int) bool { return item%2 == 0 }))
+	require.Len(t, h.undo.entries, 1)
+	require.NoError(t, h.Rollback(id))
+	require.Equal(t, 20, h.Size())
+}
@@ -0,0 +1,240 @@
+package ordered
+
+import (
+	"maps"
+	"slices"
+)
+
+// cloneItems returns copy of items. Items are copied with copyItem if it is not nil
+func cloneItems[T any](items []T, copyItem func(item T) T) []T {
+	res := slices.Clone(items)
+	if copyItem != nil {
+		for i, item := range res {
+			res[i] = copyItem(item)
+		}
+	}
+	return res
+}
+
+// clone returns copy of heap with own items. Items kept in storage are copied into slice
+func (h *baseHeap[T]) clone(copyItem func(item T) T) baseHeap[T] {
+	c := *h
+	if h.storage != nil {
+		c.storage, c.items = nil, h.snapshot()
+	}
+	c.items = cloneItems(c.items, copyItem)
+	c.undo = nil
+	return c
+}
+
+// clone returns copy of priority queue with own items
+func (h *baseWeightedPQ[T]) clone(copyItem func(item T) T) baseWeightedPQ[T] {
+	c := *h
+	c.baseHeap = h.baseHeap.clone(copyItem)
+	return c
+}
+
+// clone returns copy of heap with own items, keys and index. copyItem should keep item key
+func (h *baseUniqueHeap[T, K]) clone(copyItem func(item T) T) baseUniqueHeap[T, K] {
+	c := *h
+	c.baseHeap = h.baseHeap.clone(copyItem)
+	c.keys = slices.Clone(h.keys)
+	c.index = maps.Clone(h.index)
+	return c
+}
+
+// clone returns copy of heap with own items. Only items kept in heap are copied with copyItem
+func (h *baseBlockHeap[T]) clone(copyItem func(item T) T) baseBlockHeap[T] {
+	c := *h
+	c.items = slices.Clone(h.items)
+	if copyItem != nil {
+		for i := 1; i <= c.size; i++ {
+			pos := c.position(i)
+			c.items[pos] = copyItem(c.items[pos])
+		}
+	}
+	return c
+}
+
+// clone returns copy of heap with own keys and values. Only values kept in heap are copied with copyValue
+func (h *baseKeyedHeap[K, V]) clone(copyValue func(value V) V) baseKeyedHeap[K, V] {
+	c := *h
+	c.keys = slices.Clone(h.keys)
+	c.refs = slices.Clone(h.refs)
+	c.values = slices.Clone(h.values)
+	c.free = slices.Clone(h.free)
+	if copyValue != nil {
+		for _, ref := range c.refs {
+			c.values[ref] = copyValue(c.values[ref])
+		}
+	}
+	return c
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MinHeap[T]) Clone() MinHeap[T] {
+	return MinHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MaxHeap[T]) Clone() MaxHeap[T] {
+	return MaxHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MinPQ[T]) Clone() MinPQ[T] {
+	return MinPQ[T]{baseHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment.
+// Items kept in storage are copied into slice
+func (h *MaxPQ[T]) Clone() MaxPQ[T] {
+	return MaxPQ[T]{baseHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *WeightedMinPQ[T]) Clone() WeightedMinPQ[T] {
+	return WeightedMinPQ[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *WeightedMaxPQ[T]) Clone() WeightedMaxPQ[T] {
+	return WeightedMaxPQ[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *UniqueMinHeap[T, K]) Clone() UniqueMinHeap[T, K] {
+	return UniqueMinHeap[T, K]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *UniqueMaxHeap[T, K]) Clone() UniqueMaxHeap[T, K] {
+	return UniqueMaxHeap[T, K]{h.clone(nil)}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *UniqueMinPQ[T, K]) Clone() UniqueMinPQ[T, K] {
+	return UniqueMinPQ[T, K]{baseUniqueHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of priority queue. Items themselves are copied by assignment
+func (h *UniqueMaxPQ[T, K]) Clone() UniqueMaxPQ[T, K] {
+	return UniqueMaxPQ[T, K]{baseUniqueHeap: h.clone(nil), size: h.size}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *BlockMinHeap[T]) Clone() BlockMinHeap[T] {
+	return BlockMinHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *BlockMaxHeap[T]) Clone() BlockMaxHeap[T] {
+	return BlockMaxHeap[T]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Values themselves are copied by assignment
+func (h *KeyedMinHeap[K, V]) Clone() KeyedMinHeap[K, V] {
+	return KeyedMinHeap[K, V]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Values themselves are copied by assignment
+func (h *KeyedMaxHeap[K, V]) Clone() KeyedMaxHeap[K, V] {
+	return KeyedMaxHeap[K, V]{h.clone(nil)}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *MinHeapBy[T, K]) Clone() MinHeapBy[T, K] {
+	return MinHeapBy[T, K]{keyed: h.keyed.Clone(), key: h.key}
+}
+
+// Clone returns independent copy of heap. Items themselves are copied by assignment
+func (h *MaxHeapBy[T, K]) Clone() MaxHeapBy[T, K] {
+	return MaxHeapBy[T, K]{keyed: h.keyed.Clone(), key: h.key}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MinHeap[T]) CloneFunc(copyItem func(item T) T) MinHeap[T] {
+	return MinHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MaxHeap[T]) CloneFunc(copyItem func(item T) T) MaxHeap[T] {
+	return MaxHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MinPQ[T]) CloneFunc(copyItem func(item T) T) MinPQ[T] {
+	return MinPQ[T]{baseHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem.
+// Items kept in storage are copied into slice
+func (h *MaxPQ[T]) CloneFunc(copyItem func(item T) T) MaxPQ[T] {
+	return MaxPQ[T]{baseHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *WeightedMinPQ[T]) CloneFunc(copyItem func(item T) T) WeightedMinPQ[T] {
+	return WeightedMinPQ[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *WeightedMaxPQ[T]) CloneFunc(copyItem func(item T) T) WeightedMaxPQ[T] {
+	return WeightedMaxPQ[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *UniqueMinHeap[T, K]) CloneFunc(copyItem func(item T) T) UniqueMinHeap[T, K] {
+	return UniqueMinHeap[T, K]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *UniqueMaxHeap[T, K]) CloneFunc(copyItem func(item T) T) UniqueMaxHeap[T, K] {
+	return UniqueMaxHeap[T, K]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *UniqueMinPQ[T, K]) CloneFunc(copyItem func(item T) T) UniqueMinPQ[T, K] {
+	return UniqueMinPQ[T, K]{baseUniqueHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of priority queue. Items are copied with copyItem
+func (h *UniqueMaxPQ[T, K]) CloneFunc(copyItem func(item T) T) UniqueMaxPQ[T, K] {
+	return UniqueMaxPQ[T, K]{baseUniqueHeap: h.clone(copyItem), size: h.size}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *BlockMinHeap[T]) CloneFunc(copyItem func(item T) T) BlockMinHeap[T] {
+	return BlockMinHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *BlockMaxHeap[T]) CloneFunc(copyItem func(item T) T) BlockMaxHeap[T] {
+	return BlockMaxHeap[T]{h.clone(copyItem)}
+}
+
+// CloneFunc returns independent copy of heap. Values are copied with copyValue
+func (h *KeyedMinHeap[K, V]) CloneFunc(copyValue func(value V) V) KeyedMinHeap[K, V] {
+	return KeyedMinHeap[K, V]{h.clone(copyValue)}
+}
+
+// CloneFunc returns independent copy of heap. Values are copied with copyValue
+func (h *KeyedMaxHeap[K, V]) CloneFunc(copyValue func(value V) V) KeyedMaxHeap[K, V] {
+	return KeyedMaxHeap[K, V]{h.clone(copyValue)}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *MinHeapBy[T, K]) CloneFunc(copyItem func(item T) T) MinHeapBy[T, K] {
+	return MinHeapBy[T, K]{keyed: h.keyed.CloneFunc(copyItem), key: h.key}
+}
+
+// CloneFunc returns independent copy of heap. Items are copied with copyItem
+func (h *MaxHeapBy[T, K]) CloneFunc(copyItem func(item T) T) MaxHeapBy[T, K] {
+	return MaxHeapBy[T, K]{keyed: h.keyed.CloneFunc(copyItem), key: h.key}
+}
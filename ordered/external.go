@@ -0,0 +1,342 @@
+package ordered
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/trezorg/heap/comparable"
+	"golang.org/x/exp/constraints"
+)
+
+// run is sorted sequence of items spilled into temporary file
+type run[T constraints.Ordered] struct {
+	file    *os.File
+	decoder *gob.Decoder
+	head    T
+	// left is number of items not read from file yet
+	left int
+	// level is number of merges items of run went through, spilled runs have level 0
+	level int
+}
+
+// Less compares runs by their current head items as memory buffer compares items
+func (r *run[T]) Less(other *run[T]) bool {
+	return minCheck(r.head, other.head)
+}
+
+// next reads the next item of the run into head. Returns false when run is exhausted
+func (r *run[T]) next() (bool, error) {
+	var item T
+	if err := r.decoder.Decode(&item); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot read run %s: %w", r.file.Name(), err)
+	}
+	r.head = item
+	r.left--
+	return true, nil
+}
+
+// close closes and removes run file
+func (r *run[T]) close() error {
+	return errors.Join(r.file.Close(), os.Remove(r.file.Name()))
+}
+
+const (
+	// mergeFanIn is number of runs of the same level which are merged into one run of the next level
+	mergeFanIn = 8
+	// defaultLimit is number of items kept in memory by zero value of external heap
+	defaultLimit = 1 << 16
+)
+
+// ExternalMinHeap is min heap for datasets larger than memory. It keeps at most limit
+// items in memory buffer and spills sorted runs into temporary files, which are merged lazily on Pop.
+// Runs are merged in tiers: mergeFanIn runs of the same level are merged into one run of the next level,
+// so every item is rewritten O(log runs) times and less than mergeFanIn run files of every level are open.
+// Push rejects NaN items, which have no order, so memory buffer, runs and their merge order items the same way.
+// Zero value is empty heap keeping 65536 items in memory and writing runs into default directory for temporary files
+type ExternalMinHeap[T constraints.Ordered] struct {
+	buffer MinHeap[T]
+	runs   comparable.MinHeap[*run[T]]
+	// levels keeps number of runs of every level
+	levels []int
+	dir    string
+	limit  int
+	size   int
+}
+
+// NewExternalMinHeap creates external min heap keeping at most limit items in memory.
+// Runs are written into dir, empty dir means default directory for temporary files
+func NewExternalMinHeap[T constraints.Ordered](limit int, dir string) (*ExternalMinHeap[T], error) {
+	if limit < 1 {
+		return nil, fmt.Errorf("wrong value for limit: %d. Cannot be less than 1", limit)
+	}
+	buffer, err := NewMinHeap[T](2)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := comparable.NewMinHeap[*run[T]](2)
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalMinHeap[T]{
+		buffer: buffer,
+		runs:   runs,
+		dir:    dir,
+		limit:  limit,
+	}, nil
+}
+
+// writeRun writes items passed to encode by write into new run file in dir and reads the first of them back.
+// Write returns number of written items. Returns nil run if nothing is written
+func writeRun[T constraints.Ordered](dir string, write func(encode func(item T) error) (int, error)) (*run[T], error) {
+	file, err := os.CreateTemp(dir, "heap-run-*")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create run: %w", err)
+	}
+	r := &run[T]{file: file}
+	fail := func(err error) (*run[T], error) {
+		return nil, errors.Join(err, r.close())
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := gob.NewEncoder(writer)
+	n, err := write(func(item T) error {
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("cannot write run %s: %w", file.Name(), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fail(err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fail(fmt.Errorf("cannot write run %s: %w", file.Name(), err))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fail(fmt.Errorf("cannot rewind run %s: %w", file.Name(), err))
+	}
+
+	r.decoder = gob.NewDecoder(bufio.NewReader(file))
+	r.left = n
+	ok, err := r.next()
+	if err != nil {
+		return fail(err)
+	}
+	if !ok {
+		return nil, r.close()
+	}
+	return r, nil
+}
+
+// spill writes memory buffer into new sorted run file. Buffer is sorted in place, which keeps it a heap,
+// and is cleared only after the run is written, so no items are lost on error.
+// Runs are merged afterwards when their level is full
+func (h *ExternalMinHeap[T]) spill() error {
+	items := h.buffer.items
+	slices.SortFunc(items, func(item1, item2 T) int {
+		switch {
+		case minCheck(item1, item2):
+			return -1
+		case minCheck(item2, item1):
+			return 1
+		}
+		return 0
+	})
+	r, err := writeRun(h.dir, func(encode func(item T) error) (int, error) {
+		for _, item := range items {
+			if err := encode(item); err != nil {
+				return 0, err
+			}
+		}
+		return len(items), nil
+	})
+	if err != nil {
+		return err
+	}
+	h.buffer.Clear()
+	if r != nil {
+		h.add(r)
+	}
+	return h.compact()
+}
+
+// add pushes run into heap of runs and counts it in its level
+func (h *ExternalMinHeap[T]) add(r *run[T]) {
+	if r.level == len(h.levels) {
+		h.levels = append(h.levels, 0)
+	}
+	h.levels[r.level]++
+	h.runs.Push(r)
+}
+
+// drop closes exhausted or broken run, which is already out of heap of runs, and uncounts it
+func (h *ExternalMinHeap[T]) drop(r *run[T]) error {
+	h.levels[r.level]--
+	return r.close()
+}
+
+// compact merges full levels starting from the lowest one. Merge fills at most the next level,
+// so the first level which is not full ends compaction
+func (h *ExternalMinHeap[T]) compact() error {
+	for level := 0; level < len(h.levels) && h.levels[level] >= mergeFanIn; level++ {
+		if err := h.merge(level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merge merges runs of level into one run of the next level.
+// When merged run cannot be written, items moved into it are lost and are not counted in Size anymore,
+// the rest of runs of level are kept
+func (h *ExternalMinHeap[T]) merge(level int) error {
+	var group comparable.MinHeap[*run[T]]
+	group.Heapify(h.runs.Extract(func(r *run[T]) bool {
+		return r.level == level
+	})...)
+	var readErr error
+	written := 0
+	r, err := writeRun(h.dir, func(encode func(item T) error) (int, error) {
+		for !group.Empty() {
+			src := group.Pop()
+			if err := encode(src.head); err != nil {
+				group.Push(src)
+				return written, err
+			}
+			written++
+			ok, err := src.next()
+			switch {
+			case err != nil:
+				h.size -= src.left
+				readErr = errors.Join(readErr, err, h.drop(src))
+			case ok:
+				group.Push(src)
+			default:
+				readErr = errors.Join(readErr, h.drop(src))
+			}
+		}
+		return written, nil
+	})
+	for !group.Empty() {
+		h.runs.Push(group.Pop())
+	}
+	if err != nil {
+		h.size -= written
+		return errors.Join(readErr, err)
+	}
+	if r != nil {
+		r.level = level + 1
+		h.add(r)
+	}
+	return readErr
+}
+
+// Push adds item into heap. Memory buffer is spilled into run file when it is full.
+// Returns ErrNaN for NaN item
+func (h *ExternalMinHeap[T]) Push(item T) error {
+	if err := rejectNaN(true, item); err != nil {
+		return err
+	}
+	if h.limit == 0 {
+		h.limit = defaultLimit
+	}
+	if h.buffer.Size() >= h.limit {
+		if err := h.spill(); err != nil {
+			return err
+		}
+	}
+	h.buffer.Push(item)
+	h.size++
+	return nil
+}
+
+// fromBuffer either the next min value is in memory buffer
+func (h *ExternalMinHeap[T]) fromBuffer() bool {
+	if h.runs.Empty() {
+		return true
+	}
+	return !h.buffer.Empty() && !minCheck(h.runs.Pick().head, h.buffer.Pick())
+}
+
+// Pop returns and deletes min value. When error is returned the value is still valid,
+// but the rest of the run it belongs to is lost and is not counted in Size anymore
+func (h *ExternalMinHeap[T]) Pop() (T, error) {
+	if h.Empty() {
+		panic(ErrEmpty)
+	}
+	h.size--
+	if h.fromBuffer() {
+		return h.buffer.Pop(), nil
+	}
+	r := h.runs.Pop()
+	item := r.head
+	ok, err := r.next()
+	if err != nil {
+		h.size -= r.left
+		return item, errors.Join(err, h.drop(r))
+	}
+	if !ok {
+		return item, h.drop(r)
+	}
+	h.runs.Push(r)
+	return item, nil
+}
+
+// Pick returns min value
+func (h *ExternalMinHeap[T]) Pick() T {
+	if h.Empty() {
+		panic(ErrEmpty)
+	}
+	if h.fromBuffer() {
+		return h.buffer.Pick()
+	}
+	return h.runs.Pick().head
+}
+
+// Empty either heap is blank
+func (h *ExternalMinHeap[T]) Empty() bool {
+	return h.size == 0
+}
+
+// Size returns heap size
+func (h *ExternalMinHeap[T]) Size() int {
+	return h.size
+}
+
+// Runs returns number of runs spilled into files and not merged yet
+func (h *ExternalMinHeap[T]) Runs() int {
+	return h.runs.Size()
+}
+
+// Close removes all run files and clears heap
+func (h *ExternalMinHeap[T]) Close() error {
+	var err error
+	for !h.runs.Empty() {
+		err = errors.Join(err, h.runs.Pop().close())
+	}
+	h.levels = nil
+	h.buffer.Heapify()
+	h.size = 0
+	return err
+}
+
+// Validate checks heap property of memory buffer and of heap of run heads.
+// Returns *heap.InvariantError with level order indexes of the first item ordered before its parent.
+// External heap has no Height, Factor and Levels and does not implement heap.Inspector:
+// items are split between memory buffer and sorted runs on disk, which do not form one heap
+func (h *ExternalMinHeap[T]) Validate() error {
+	if err := h.buffer.Validate(); err != nil {
+		return fmt.Errorf("memory buffer: %w", err)
+	}
+	if err := h.runs.Validate(); err != nil {
+		return fmt.Errorf("runs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package ordered
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalMinHeap(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewExternalMinHeap[int](10, dir)
+	require.NoError(t, err)
+
+	items := rand.Perm(1000)
+	for _, item := range items {
+		require.NoError(t, h.Push(item))
+	}
+	require.Equal(t, 1000, h.Size())
+	require.Greater(t, h.Runs(), 1)
+	require.Equal(t, 0, h.Pick())
+	require.NoError(t, h.Validate())
+
+	sort.Ints(items)
+	for _, expected := range items {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		require.Equal(t, expected, item)
+	}
+	require.True(t, h.Empty())
+	require.Panics(t, func() { _, _ = h.Pop() })
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestExternalMinHeapInterleaved(t *testing.T) {
+	h, err := NewExternalMinHeap[string](3, t.TempDir())
+	require.NoError(t, err)
+
+	for _, item := range []string{"e", "d", "c", "b", "a", "f"} {
+		require.NoError(t, h.Push(item))
+	}
+	item, err := h.Pop()
+	require.NoError(t, err)
+	require.Equal(t, "a", item)
+
+	require.NoError(t, h.Push("a"))
+	require.NoError(t, h.Push("g"))
+
+	var res []string
+	for !h.Empty() {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		res = append(res, item)
+	}
+	require.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g"}, res)
+}
+
+func TestExternalMinHeapClose(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewExternalMinHeap[float64](2, dir)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, h.Push(float64(i)))
+	}
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	require.NoError(t, h.Close())
+	require.True(t, h.Empty())
+	files, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestExternalMinHeapRejectsNaN(t *testing.T) {
+	h, err := NewExternalMinHeap[float64](2, t.TempDir())
+	require.NoError(t, err)
+	defer h.Close()
+	for _, item := range []float64{3, math.Inf(1), 1, 0, math.Inf(-1)} {
+		require.NoError(t, h.Push(item))
+	}
+	require.True(t, errors.Is(h.Push(math.NaN()), ErrNaN))
+	require.Equal(t, 5, h.Size())
+	require.Equal(t, 2, h.Runs())
+
+	var res []float64
+	for !h.Empty() {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		res = append(res, item)
+	}
+	require.Equal(t, []float64{math.Inf(-1), 0, 1, 3, math.Inf(1)}, res)
+}
+
+func TestExternalMinHeapZeroValue(t *testing.T) {
+	var h ExternalMinHeap[int]
+	items := rand.Perm(1000)
+	for _, item := range items {
+		require.NoError(t, h.Push(item))
+	}
+	require.Equal(t, 0, h.Runs())
+	require.Equal(t, defaultLimit, h.limit)
+	for expected := range items {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		require.Equal(t, expected, item)
+	}
+	require.NoError(t, h.Close())
+}
+
+func TestExternalMinHeapWrongLimit(t *testing.T) {
+	_, err := NewExternalMinHeap[int](0, "")
+	require.Error(t, err)
+}
+
+func TestExternalMinHeapSpillError(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "runs")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	h, err := NewExternalMinHeap[int](5, dir)
+	require.NoError(t, err)
+	for _, item := range rand.Perm(5) {
+		require.NoError(t, h.Push(item))
+	}
+	require.NoError(t, os.Remove(dir))
+	require.Error(t, h.Push(5))
+	require.Equal(t, 5, h.Size())
+	require.NoError(t, h.Validate())
+
+	var res []int
+	for !h.Empty() {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		res = append(res, item)
+	}
+	require.Equal(t, []int{0, 1, 2, 3, 4}, res)
+}
+
+func TestExternalMinHeapBrokenRun(t *testing.T) {
+	h, err := NewExternalMinHeap[int](2000, t.TempDir())
+	require.NoError(t, err)
+	for i := range 4000 {
+		require.NoError(t, h.Push(i))
+	}
+	require.Equal(t, 1, h.Runs())
+	require.NoError(t, h.runs.Pick().file.Truncate(5000))
+
+	failed := false
+	popped := 0
+	for !h.Empty() {
+		_, err := h.Pop()
+		popped++
+		if err != nil {
+			failed = true
+			require.Equal(t, 2000, h.Size())
+		}
+	}
+	require.True(t, failed)
+	require.Less(t, popped, 4000)
+}
+
+func TestExternalMinHeapMergesRuns(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewExternalMinHeap[int](10, dir)
+	require.NoError(t, err)
+	items := rand.Perm(5000)
+	for _, item := range items {
+		require.NoError(t, h.Push(item))
+		for _, runs := range h.levels {
+			require.Less(t, runs, mergeFanIn)
+		}
+	}
+	// 499 spilled runs make 3 levels of merged runs
+	require.Len(t, h.levels, 3)
+	require.Less(t, h.Runs(), mergeFanIn*len(h.levels))
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, h.Runs(), len(files))
+	require.Equal(t, 5000, h.Size())
+
+	sort.Ints(items)
+	for _, expected := range items {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		require.Equal(t, expected, item)
+	}
+	require.True(t, h.Empty())
+}
@@ -0,0 +1,438 @@
+package ordered
+
+// removeIf deletes items matching pred in place and restores heap with one heapify pass.
+// Items are saved for rollback only if any of them matches.
+// Returns number of deleted items and deleted items themselves if extract is set
+func (h *baseHeap[T]) removeIf(pred func(item T) bool, extract bool) (int, []T) {
+	size := h.len()
+	n := 0
+	for n < size && !pred(h.at(n)) {
+		n++
+	}
+	if n == size {
+		return 0, nil
+	}
+	h.save()
+	var removed []T
+	if extract {
+		removed = append(removed, h.at(n))
+	}
+	for i := n + 1; i < size; i++ {
+		item := h.at(i)
+		if pred(item) {
+			if extract {
+				removed = append(removed, item)
+			}
+			continue
+		}
+		h.put(n, item)
+		n++
+	}
+	h.drop(n)
+	h.rebuild()
+	return size - n, removed
+}
+
+// removeIf deletes items matching pred with their keys and restores heap with one heapify pass.
+// Returns number of deleted items and deleted items themselves if extract is set
+func (h *baseUniqueHeap[T, K]) removeIf(pred func(item T) bool, extract bool) (int, []T) {
+	var removed []T
+	n := 0
+	for i, item := range h.items {
+		key := h.keys[i]
+		if pred(item) {
+			delete(h.index, key)
+			if extract {
+				removed = append(removed, item)
+			}
+			continue
+		}
+		h.set(n, item, key)
+		n++
+	}
+	count := len(h.items) - n
+	if count > 0 {
+		clear(h.items[n:])
+		clear(h.keys[n:])
+		h.items, h.keys = h.items[:n], h.keys[:n]
+		h.rebuild()
+	}
+	return count, removed
+}
+
+// removeIf deletes items matching pred and subtracts their weight from total weight
+func (h *baseWeightedPQ[T]) removeIf(pred func(item T) bool, extract bool) (int, []T) {
+	return h.baseHeap.removeIf(func(item T) bool {
+		if !pred(item) {
+			return false
+		}
+		h.weight -= h.weigh(item)
+		return true
+	}, extract)
+}
+
+// removeIf deletes items matching pred and lays out the rest with one heapify pass.
+// Returns number of deleted items and deleted items themselves if extract is set
+func (h *baseBlockHeap[T]) removeIf(pred func(item T) bool, extract bool) (int, []T) {
+	var kept, removed []T
+	for i := 0; i < h.size; i++ {
+		item := h.at(i)
+		if !pred(item) {
+			kept = append(kept, item)
+		} else if extract {
+			removed = append(removed, item)
+		}
+	}
+	count := h.size - len(kept)
+	if count > 0 {
+		h.heapify(kept...)
+	}
+	return count, removed
+}
+
+// removeIf deletes values matching pred with their keys, frees their payload slots
+// and restores heap with one heapify pass.
+// Returns number of deleted values and deleted keys and values themselves if extract is set
+func (h *baseKeyedHeap[K, V]) removeIf(pred func(key K, value V) bool, extract bool) (int, []K, []V) {
+	var removedKeys []K
+	var removedValues []V
+	var zero V
+	n := 0
+	for i, key := range h.keys {
+		ref := h.refs[i]
+		if pred(key, h.values[ref]) {
+			if extract {
+				removedKeys = append(removedKeys, key)
+				removedValues = append(removedValues, h.values[ref])
+			}
+			h.values[ref] = zero
+			h.free = append(h.free, ref)
+			continue
+		}
+		h.keys[n], h.refs[n] = key, ref
+		n++
+	}
+	count := len(h.keys) - n
+	if count == 0 {
+		return 0, nil, nil
+	}
+	clear(h.keys[n:])
+	h.keys, h.refs = h.keys[:n], h.refs[:n]
+	if n == 0 {
+		h.values, h.free = h.values[:0], h.free[:0]
+		return count, removedKeys, removedValues
+	}
+	for i := (n - 1) / h.factor; i >= 0; i-- {
+		h.down(i)
+	}
+	return count, removedKeys, removedValues
+}
+
+// values adapts item predicate of heap by key to predicate of keyed heap
+func values[K any, T any](pred func(item T) bool) func(key K, item T) bool {
+	return func(_ K, item T) bool {
+		return pred(item)
+	}
+}
+
+// retain returns predicate matching items which are not kept by pred
+func retain[T any](pred func(item T) bool) func(item T) bool {
+	return func(item T) bool {
+		return !pred(item)
+	}
+}
+
+// retainKeyed returns predicate matching values which are not kept by pred
+func retainKeyed[K any, V any](pred func(key K, value V) bool) func(key K, value V) bool {
+	return func(key K, value V) bool {
+		return !pred(key, value)
+	}
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MinHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MaxHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MinPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MaxPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMinHeap[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMaxHeap[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMinPQ[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *UniqueMaxPQ[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *WeightedMinPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *WeightedMaxPQ[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *BlockMinHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *BlockMaxHeap[T]) RemoveIf(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes values matching pred with their keys. Returns number of deleted values
+func (h *KeyedMinHeap[K, V]) RemoveIf(pred func(key K, value V) bool) int {
+	count, _, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes values matching pred with their keys. Returns number of deleted values
+func (h *KeyedMaxHeap[K, V]) RemoveIf(pred func(key K, value V) bool) int {
+	count, _, _ := h.base().removeIf(pred, false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MinHeapBy[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _, _ := h.keyed.base().removeIf(values[K](pred), false)
+	return count
+}
+
+// RemoveIf deletes items matching pred. Returns number of deleted items
+func (h *MaxHeapBy[T, K]) RemoveIf(pred func(item T) bool) int {
+	count, _, _ := h.keyed.base().removeIf(values[K](pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MinHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MaxHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MinPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MaxPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMinHeap[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMaxHeap[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMinPQ[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *UniqueMaxPQ[T, K]) Retain(pred func(item T) bool) int {
+	count, _ := h.removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *WeightedMinPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *WeightedMaxPQ[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *BlockMinHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *BlockMaxHeap[T]) Retain(pred func(item T) bool) int {
+	count, _ := h.base().removeIf(retain(pred), false)
+	return count
+}
+
+// Retain keeps only values matching pred with their keys. Returns number of deleted values
+func (h *KeyedMinHeap[K, V]) Retain(pred func(key K, value V) bool) int {
+	count, _, _ := h.base().removeIf(retainKeyed(pred), false)
+	return count
+}
+
+// Retain keeps only values matching pred with their keys. Returns number of deleted values
+func (h *KeyedMaxHeap[K, V]) Retain(pred func(key K, value V) bool) int {
+	count, _, _ := h.base().removeIf(retainKeyed(pred), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MinHeapBy[T, K]) Retain(pred func(item T) bool) int {
+	count, _, _ := h.keyed.base().removeIf(values[K](retain(pred)), false)
+	return count
+}
+
+// Retain keeps only items matching pred. Returns number of deleted items
+func (h *MaxHeapBy[T, K]) Retain(pred func(item T) bool) int {
+	count, _, _ := h.keyed.base().removeIf(values[K](retain(pred)), false)
+	return count
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MinHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MaxHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MinPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MaxPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMinHeap[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMaxHeap[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMinPQ[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *UniqueMaxPQ[T, K]) Extract(pred func(item T) bool) []T {
+	_, removed := h.removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *WeightedMinPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *WeightedMaxPQ[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *BlockMinHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *BlockMaxHeap[T]) Extract(pred func(item T) bool) []T {
+	_, removed := h.base().removeIf(pred, true)
+	return removed
+}
+
+// Extract deletes and returns values matching pred with their keys in no particular order
+func (h *KeyedMinHeap[K, V]) Extract(pred func(key K, value V) bool) ([]K, []V) {
+	_, keys, values := h.base().removeIf(pred, true)
+	return keys, values
+}
+
+// Extract deletes and returns values matching pred with their keys in no particular order
+func (h *KeyedMaxHeap[K, V]) Extract(pred func(key K, value V) bool) ([]K, []V) {
+	_, keys, values := h.base().removeIf(pred, true)
+	return keys, values
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MinHeapBy[T, K]) Extract(pred func(item T) bool) []T {
+	_, _, removed := h.keyed.base().removeIf(values[K](pred), true)
+	return removed
+}
+
+// Extract deletes and returns items matching pred in no particular order
+func (h *MaxHeapBy[T, K]) Extract(pred func(item T) bool) []T {
+	_, _, removed := h.keyed.base().removeIf(values[K](pred), true)
+	return removed
+}
@@ -1,8 +1,10 @@
 package ordered
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/trezorg/heap"
 	"golang.org/x/exp/constraints"
 )
 
@@ -10,56 +12,98 @@ type Comparator[T any] interface {
 	Less(T) bool
 }
 
-// baseHeap heap structure with values based on Ordered generic constraint
-type baseHeap[T constraints.Ordered] struct {
-	check    func(item1 T, item2 T) bool
-	getChild func(items []T, idx []int) int
-	items    []T
-	factor   int
-}
+const defaultFactor = 2
+
+// Unbounded is size of priority queue without size bound
+const Unbounded = heap.Unbounded
+
+var (
+	// ErrEmpty is panic value on pop or pick from empty heap
+	ErrEmpty = errors.New("empty base heap")
+	// ErrInvalidFactor is returned by constructors for factor less than 2
+	ErrInvalidFactor = errors.New("wrong value for factor")
+	// ErrInvalidSize is returned for priority queue size less than 1 which is not Unbounded
+	ErrInvalidSize = errors.New("wrong value for size")
+)
 
-// MinHeap is heap that returns element with min priority
+// baseHeap heap structure with values based on Ordered generic constraint.
+// Items are kept in items slice or in storage if it is set
+type baseHeap[T constraints.Ordered] struct {
+	check     func(item1 T, item2 T) bool
+	items     []T
+	storage   heap.Storage[T]
+	factor    int
+	bottomUp  bool
+	rejectNaN bool
+	evict     func(item T)
+	undo      *undoLog[T]
+}
+
+// MinHeap is heap that returns element with min priority.
+// Zero value is empty heap with factor 2
 type MinHeap[T constraints.Ordered] struct {
 	baseHeap[T]
 }
 
-// MaxHeap is heap that returns element with max priority
+// MaxHeap is heap that returns element with max priority.
+// Zero value is empty heap with factor 2
 type MaxHeap[T constraints.Ordered] struct {
 	baseHeap[T]
 }
 
-// MaxPQ is maximum bounded priority queue based on Ordered generic constraint
+// MaxPQ is maximum bounded priority queue based on Ordered generic constraint.
+// Zero value is empty unbounded priority queue
 type MaxPQ[T constraints.Ordered] struct {
 	baseHeap[T]
 	size int
 }
 
-// MinPQ is minimum bounded priority queue
+// MinPQ is minimum bounded priority queue.
+// Zero value is empty unbounded priority queue
 type MinPQ[T constraints.Ordered] struct {
 	baseHeap[T]
 	size int
 }
 
+// checkFactor returns ErrInvalidFactor if factor is less than 2
+func checkFactor(factor int) error {
+	if factor < 2 {
+		return fmt.Errorf("%w: %d. Cannot be less than 2", ErrInvalidFactor, factor)
+	}
+	return nil
+}
+
+// newHeap creates base heap. Priority queue keeps the worst item on top,
+// so NaN policy is reversed for queue
 func newHeap[T constraints.Ordered](
 	factor int,
 	check func(item1 T, item2 T) bool,
-	getChild func(items []T, idx []int) int,
+	queue bool,
+	opts []Option,
 ) (baseHeap[T], error) {
-	if factor < 2 {
-		return baseHeap[T]{}, fmt.Errorf("wrong value for factor: %d. Cannot be less than 2", factor)
+	if err := checkFactor(factor); err != nil {
+		return baseHeap[T]{}, err
 	}
 
+	o := newOptions(opts)
+	evict, err := evictCallback[T](o, queue)
+	if err != nil {
+		return baseHeap[T]{}, err
+	}
+	check, rejectNaN := nanPolicy(check, o.nan, queue)
 	return baseHeap[T]{
-		items:    make([]T, 0),
-		factor:   factor,
-		check:    check,
-		getChild: getChild,
+		items:     make([]T, 0, o.capacity),
+		factor:    factor,
+		check:     check,
+		bottomUp:  o.bottomUp,
+		rejectNaN: rejectNaN,
+		evict:     evict,
 	}, nil
 }
 
 // NewMinHeap heap constructor
-func NewMinHeap[T constraints.Ordered](factor int) (MinHeap[T], error) {
-	baseHeap, err := newHeap(factor, minCheck[T], getMinChild[T])
+func NewMinHeap[T constraints.Ordered](factor int, opts ...Option) (MinHeap[T], error) {
+	baseHeap, err := newHeap(factor, minCheck[T], false, opts)
 	if err != nil {
 		return MinHeap[T]{}, err
 	}
@@ -67,78 +111,135 @@ func NewMinHeap[T constraints.Ordered](factor int) (MinHeap[T], error) {
 }
 
 // NewMaxHeap heap constructor
-func NewMaxHeap[T constraints.Ordered](factor int) (MaxHeap[T], error) {
-	baseHeap, err := newHeap(factor, maxCheck[T], getMaxChild[T])
+func NewMaxHeap[T constraints.Ordered](factor int, opts ...Option) (MaxHeap[T], error) {
+	baseHeap, err := newHeap(factor, maxCheck[T], false, opts)
 	if err != nil {
 		return MaxHeap[T]{}, err
 	}
 	return MaxHeap[T]{baseHeap}, nil
 }
 
-// NewMaxPQ creates maximum priority Queue with heap factor 2
-func NewMaxPQ[T constraints.Ordered](size int) (MaxPQ[T], error) {
-	baseHeap, err := newHeap(2, minCheck[T], getMinChild[T])
+// NewMaxPQ creates maximum priority Queue with heap factor 2.
+// Size should be positive or Unbounded
+func NewMaxPQ[T constraints.Ordered](size int, opts ...Option) (MaxPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return MaxPQ[T]{}, err
+	}
+	baseHeap, err := newHeap(2, minCheck[T], true, opts)
 	if err != nil {
 		return MaxPQ[T]{}, err
 	}
 	return MaxPQ[T]{baseHeap: baseHeap, size: size}, nil
 }
 
-// NewMinPQ creates minimum priority Queue with heap factor 2
-func NewMinPQ[T constraints.Ordered](size int) (MinPQ[T], error) {
-	baseHeap, err := newHeap(2, maxCheck[T], getMaxChild[T])
+// NewMinPQ creates minimum priority Queue with heap factor 2.
+// Size should be positive or Unbounded
+func NewMinPQ[T constraints.Ordered](size int, opts ...Option) (MinPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return MinPQ[T]{}, err
+	}
+	baseHeap, err := newHeap(2, maxCheck[T], true, opts)
 	if err != nil {
 		return MinPQ[T]{}, err
 	}
 	return MinPQ[T]{baseHeap: baseHeap, size: size}, nil
 }
 
-func minCheck[T constraints.Ordered](item1 T, item2 T) bool {
-	return item1 < item2
+// init makes zero value heap usable with default factor
+func (h *baseHeap[T]) init(check func(item1 T, item2 T) bool) {
+	h.factor = defaultFactor
+	h.check = check
 }
 
-func maxCheck[T constraints.Ordered](item1 T, item2 T) bool {
-	return item1 > item2
+func (h *MinHeap[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(minCheck[T])
+	}
+	return &h.baseHeap
 }
 
-func checkMinMaxIndex[T constraints.Ordered](items []T, indexes []int, check func(item1 T, item2 T) bool) int {
-	if len(items) == 0 || len(indexes) == 0 {
-		return -1
+func (h *MaxHeap[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(maxCheck[T])
 	}
-	out := indexes[0]
-	if out >= len(items) {
-		return -1
+	return &h.baseHeap
+}
+
+func (h *MinPQ[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(maxCheck[T])
 	}
-	res := items[out]
-	for i := 1; i < len(indexes); i++ {
-		idx := indexes[i]
-		if idx >= len(items) {
-			continue
-		}
-		item := items[idx]
-		if check(item, res) {
-			res = item
-			out = idx
-		}
+	return &h.baseHeap
+}
+
+func (h *MaxPQ[T]) base() *baseHeap[T] {
+	if h.check == nil {
+		h.init(minCheck[T])
 	}
-	return out
+	return &h.baseHeap
 }
 
-func getMinChild[T constraints.Ordered](items []T, idx []int) int {
-	return checkMinMaxIndex(items, idx, minCheck[T])
+// full either priority queue of size cannot hold more items.
+// Size less than 1 means unbounded priority queue
+func (h *baseHeap[T]) full(size int) bool {
+	return size > 0 && h.len() >= size
 }
 
-func getMaxChild[T constraints.Ordered](items []T, idx []int) int {
-	return checkMinMaxIndex(items, idx, maxCheck[T])
+func checkSize(size int) error {
+	if size < 1 && size != Unbounded {
+		return fmt.Errorf("%w: %d. Cannot be less than 1", ErrInvalidSize, size)
+	}
+	return nil
 }
 
-func (h *baseHeap[T]) children(idx int) []int {
-	var res []int
-	for i := 0; i < h.factor; i++ {
-		child := (idx * h.factor) + i + 1
-		res = append(res, child)
+// bound returns number of items priority queue of size can hold from n items.
+// Size less than 1 means unbounded priority queue
+func bound(size, n int) int {
+	if size < 1 {
+		return n
+	}
+	return min(size, n)
+}
+
+// pushBounded adds item into priority queue of size. When priority queue is full,
+// either item or the top one with the worst priority is evicted. Returns evicted item
+func (h *baseHeap[T]) pushBounded(size int, item T) (T, bool) {
+	if !h.full(size) {
+		h.push(item)
+		var zero T
+		return zero, false
+	}
+	if h.check(item, h.at(0)) {
+		h.evicted(item)
+		return item, true
+	}
+	h.mustAccept(item)
+	evicted := h.at(0)
+	h.set(0, item)
+	h.down(0)
+	h.evicted(evicted)
+	return evicted, true
+}
+
+// evicted passes evicted item to eviction callback
+func (h *baseHeap[T]) evicted(item T) {
+	if h.evict != nil {
+		h.evict(item)
 	}
-	return res
+}
+
+func minCheck[T constraints.Ordered](item1 T, item2 T) bool {
+	return item1 < item2
+}
+
+func maxCheck[T constraints.Ordered](item1 T, item2 T) bool {
+	return item1 > item2
+}
+
+// children returns range [first, last) of idx children indexes
+func (h *baseHeap[T]) children(idx int) (int, int) {
+	first := idx*h.factor + 1
+	return first, first + h.factor
 }
 
 func parent(idx, factor int) int {
@@ -157,135 +258,347 @@ func (h *baseHeap[T]) parent(idx int) int {
 }
 
 func (h *baseHeap[T]) up(idx int) {
+	h.moved(idx, h.siftUp(idx))
+}
+
+// siftUp moves item at idx up and returns its new index
+func (h *baseHeap[T]) siftUp(idx int) int {
+	if h.storage != nil {
+		return h.siftUpStorage(idx)
+	}
 	item := h.items[idx]
-	for idx >= 0 {
+	for idx > 0 {
 		parent := h.parent(idx)
-		parentT := h.items[parent]
-		if parent != idx && h.check(item, parentT) {
-			h.items[idx] = parentT
-			idx = parent
-		} else {
-			h.items[idx] = item
+		if !h.check(item, h.items[parent]) {
 			break
 		}
+		h.items[idx] = h.items[parent]
+		idx = parent
 	}
+	h.items[idx] = item
+	return idx
 }
 
 func (h *baseHeap[T]) push(item T) {
-	h.items = append(h.items, item)
+	h.mustAccept(item)
+	h.appendItem(item)
+	if h.storage != nil {
+		h.up(h.storage.Len() - 1)
+		return
+	}
 	h.up(len(h.items) - 1)
 }
 
 func (h *baseHeap[T]) heapify(items ...T) {
-	h.items = items
-	firstParent := (len(items) - 1) / h.factor
+	h.mustAccept(items...)
+	h.save()
+	h.load(items)
+	h.rebuild()
+}
+
+// rebuild restores heap invariant for all items. Sifts are not logged,
+// so callers save items into undo log before
+func (h *baseHeap[T]) rebuild() {
+	if h.empty() {
+		return
+	}
+	firstParent := (h.len() - 1) / h.factor
 	for i := firstParent; i >= 0; i-- {
-		h.down(i)
+		h.siftDown(i)
 	}
 }
 
 func (h *baseHeap[T]) pick() T {
 	if h.empty() {
-		panic("empty base heap")
+		panic(ErrEmpty)
+	}
+	return h.at(0)
+}
+
+func (h *baseHeap[T]) tryPick() (T, bool) {
+	if h.empty() {
+		var item T
+		return item, false
+	}
+	return h.at(0), true
+}
+
+func (h *baseHeap[T]) tryPop() (T, bool) {
+	if h.empty() {
+		var item T
+		return item, false
 	}
-	return h.items[0]
+	return h.pop(), true
 }
 
 func (h *baseHeap[T]) empty() bool {
-	return len(h.items) == 0
+	return h.len() == 0
 }
 
 func (h *baseHeap[T]) len() int {
+	if h.storage != nil {
+		return h.storage.Len()
+	}
 	return len(h.items)
 }
 
 func (h *baseHeap[T]) pop() T {
 	if h.empty() {
-		panic("empty base heap")
+		panic(ErrEmpty)
+	}
+	if h.storage != nil {
+		return h.popStorage()
 	}
+	last := len(h.items) - 1
 	item := h.items[0]
-	h.items[0] = h.items[len(h.items)-1]
-	h.items = h.items[:len(h.items)-1]
+	h.set(0, h.items[last])
+	h.truncate(last)
 	h.down(0)
 	return item
 }
 
 func (h *baseHeap[T]) down(idx int) {
-	if len(h.items) == 0 {
+	if idx >= h.len() {
 		return
 	}
-	item := h.items[idx]
-	for idx < len(h.items) {
-		child := h.getChild(h.items, h.children(idx))
-		if child == -1 {
-			h.items[idx] = item
+	h.moved(idx, h.siftDown(idx))
+}
+
+// siftDown moves item at idx down and returns its new index
+func (h *baseHeap[T]) siftDown(idx int) int {
+	switch {
+	case h.storage != nil:
+		return h.siftDownStorage(idx)
+	case h.bottomUp:
+		return h.downBottomUp(idx)
+	case h.factor == 2:
+		return h.down2(idx)
+	case h.factor == 4:
+		return h.down4(idx)
+	default:
+		return h.downN(idx)
+	}
+}
+
+// downN sifts item down choosing the best of factor children
+func (h *baseHeap[T]) downN(idx int) int {
+	items := h.items
+	item := items[idx]
+	for {
+		first, last := h.children(idx)
+		if first >= len(items) {
+			break
+		}
+		child := first
+		for i := first + 1; i < min(last, len(items)); i++ {
+			if h.check(items[i], items[child]) {
+				child = i
+			}
+		}
+		if !h.check(items[child], item) {
 			break
 		}
-		childT := h.items[child]
-		if child != idx && h.check(childT, item) {
-			h.items[idx] = childT
-			idx = child
+		items[idx] = items[child]
+		idx = child
+	}
+	items[idx] = item
+	return idx
+}
+
+// downBottomUp moves hole from idx to a leaf following the best children
+// and then sifts item up from the leaf. Makes about half of comparisons of downN
+func (h *baseHeap[T]) downBottomUp(idx int) int {
+	items := h.items
+	item := items[idx]
+	start := idx
+	for {
+		first, last := h.children(idx)
+		if first >= len(items) {
+			break
+		}
+		child := first
+		for i := first + 1; i < min(last, len(items)); i++ {
+			if h.check(items[i], items[child]) {
+				child = i
+			}
+		}
+		items[idx] = items[child]
+		idx = child
+	}
+	for idx > start {
+		parent := h.parent(idx)
+		if !h.check(item, items[parent]) {
+			break
+		}
+		items[idx] = items[parent]
+		idx = parent
+	}
+	items[idx] = item
+	return idx
+}
+
+// down2 sifts item down in binary heap
+func (h *baseHeap[T]) down2(idx int) int {
+	items := h.items
+	item := items[idx]
+	for {
+		child := 2*idx + 1
+		if child >= len(items) {
+			break
+		}
+		if right := child + 1; right < len(items) && h.check(items[right], items[child]) {
+			child = right
+		}
+		if !h.check(items[child], item) {
+			break
+		}
+		items[idx] = items[child]
+		idx = child
+	}
+	items[idx] = item
+	return idx
+}
+
+// down4 sifts item down in 4-ary heap comparing children in pairs
+func (h *baseHeap[T]) down4(idx int) int {
+	items := h.items
+	item := items[idx]
+	for {
+		first := 4*idx + 1
+		if first >= len(items) {
+			break
+		}
+		child := first
+		if first+3 < len(items) {
+			if h.check(items[first+1], items[child]) {
+				child = first + 1
+			}
+			other := first + 2
+			if h.check(items[first+3], items[other]) {
+				other = first + 3
+			}
+			if h.check(items[other], items[child]) {
+				child = other
+			}
 		} else {
-			h.items[idx] = item
+			for i := first + 1; i < len(items); i++ {
+				if h.check(items[i], items[child]) {
+					child = i
+				}
+			}
+		}
+		if !h.check(items[child], item) {
 			break
 		}
+		items[idx] = items[child]
+		idx = child
 	}
+	items[idx] = item
+	return idx
 }
 
 // Push adds item into heap
 func (h *MinHeap[T]) Push(item T) {
-	h.push(item)
+	h.base().push(item)
 }
 
 // Push adds item into heap
 func (h *MaxHeap[T]) Push(item T) {
-	h.push(item)
+	h.base().push(item)
 }
 
 // Push adds item into priority queue
 func (h *MinPQ[T]) Push(item T) {
-	if h.len() < h.size {
-		h.push(item)
-		return
-	}
-	if h.pick() < item {
-		return
-	}
-	h.items[0] = item
-	h.down(0)
+	h.base().pushBounded(h.size, item)
 }
 
 // Push adds item into priority queue
 func (h *MaxPQ[T]) Push(item T) {
-	if h.len() < h.size {
-		h.push(item)
-		return
-	}
-	if h.pick() > item {
-		return
-	}
-	h.items[0] = item
-	h.down(0)
+	h.base().pushBounded(h.size, item)
+}
+
+// PushEvict adds item into priority queue. When priority queue is full, returns evicted item,
+// which is either item itself or the current max value
+func (h *MinPQ[T]) PushEvict(item T) (T, bool) {
+	return h.base().pushBounded(h.size, item)
+}
+
+// OnEvict sets callback receiving items evicted from priority queue on Push, PushEvict and Heapify.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *MinPQ[T]) OnEvict(evict func(item T)) {
+	h.base().evict = evict
+}
+
+// PushEvict adds item into priority queue. When priority queue is full, returns evicted item,
+// which is either item itself or the current min value
+func (h *MaxPQ[T]) PushEvict(item T) (T, bool) {
+	return h.base().pushBounded(h.size, item)
+}
+
+// OnEvict sets callback receiving items evicted from priority queue on Push, PushEvict and Heapify.
+// It replaces callback set by WithEvict, nil callback removes it
+func (h *MaxPQ[T]) OnEvict(evict func(item T)) {
+	h.base().evict = evict
 }
 
 // Pop returns and deletes min value
 func (h *MinHeap[T]) Pop() T {
-	return h.pop()
+	return h.base().pop()
 }
 
 // Pop returns and deletes max value
 func (h *MaxHeap[T]) Pop() T {
-	return h.pop()
+	return h.base().pop()
 }
 
 // Pop returns and deletes min value
 func (h *MinPQ[T]) Pop() T {
-	return h.pop()
+	return h.base().pop()
 }
 
 // Pop returns and deletes max value
 func (h *MaxPQ[T]) Pop() T {
-	return h.pop()
+	return h.base().pop()
+}
+
+// TryPop returns and deletes min value. Returns false if heap is empty
+func (h *MinHeap[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes max value. Returns false if heap is empty
+func (h *MaxHeap[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes min value. Returns false if priority queue is empty
+func (h *MinPQ[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes max value. Returns false if priority queue is empty
+func (h *MaxPQ[T]) TryPop() (T, bool) {
+	return h.base().tryPop()
+}
+
+// TryPick returns min value. Returns false if heap is empty
+func (h *MinHeap[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns max value. Returns false if heap is empty
+func (h *MaxHeap[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns min value. Returns false if priority queue is empty
+func (h *MinPQ[T]) TryPick() (T, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns max value. Returns false if priority queue is empty
+func (h *MaxPQ[T]) TryPick() (T, bool) {
+	return h.tryPick()
 }
 
 // Pick returns min value
@@ -330,35 +643,31 @@ func (h *MaxPQ[T]) Empty() bool {
 
 // Heapify initializes heap
 func (h *MinHeap[T]) Heapify(items ...T) {
-	h.heapify(items...)
+	h.base().heapify(items...)
 }
 
 // Heapify initializes  heap
 func (h *MaxHeap[T]) Heapify(items ...T) {
-	h.heapify(items...)
+	h.base().heapify(items...)
 }
 
-// Heapify initializes  priority queue
+// Heapify initializes priority queue
 func (h *MinPQ[T]) Heapify(items ...T) {
-	h.heapify(items[:min(h.size, len(items))]...)
-	for i := h.size; i < len(items); i++ {
-		if h.pick() < items[i] {
-			continue
-		}
-		h.items[0] = items[i]
-		h.down(0)
+	size := bound(h.size, len(items))
+	h.base().mustAccept(items...)
+	h.heapify(items[:size]...)
+	for _, item := range items[size:] {
+		h.pushBounded(h.size, item)
 	}
 }
 
-// Heapify initializes  priority queue
+// Heapify initializes priority queue
 func (h *MaxPQ[T]) Heapify(items ...T) {
-	h.heapify(items[:min(h.size, len(items))]...)
-	for i := h.size; i < len(items); i++ {
-		if h.pick() > items[i] {
-			continue
-		}
-		h.items[0] = items[i]
-		h.down(0)
+	size := bound(h.size, len(items))
+	h.base().mustAccept(items...)
+	h.heapify(items[:size]...)
+	for _, item := range items[size:] {
+		h.pushBounded(h.size, item)
 	}
 }
 
@@ -374,12 +683,12 @@ func (h *MaxHeap[T]) Size() int {
 
 // Slice returns heap slice
 func (h *MinHeap[T]) Slice() []T {
-	return h.slice()
+	return h.base().slice()
 }
 
 // Slice returns heap slice
 func (h *MaxHeap[T]) Slice() []T {
-	return h.slice()
+	return h.base().slice()
 }
 
 // Size returns priority queue size
@@ -392,6 +701,58 @@ func (h *MaxPQ[T]) Size() int {
 	return h.len()
 }
 
+// Capacity returns priority queue size bound or Unbounded
+func (h *MinPQ[T]) Capacity() int {
+	if h.size < 1 {
+		return Unbounded
+	}
+	return h.size
+}
+
+// SetCapacity changes priority queue size bound. Shrinking evicts items starting from the max value.
+// Evicted items are passed to eviction callback and returned in eviction order. Size should be positive or Unbounded
+func (h *MinPQ[T]) SetCapacity(size int) ([]T, error) {
+	if err := checkSize(size); err != nil {
+		return nil, err
+	}
+	h.base().bounded(h.size)
+	h.size = size
+	return h.base().shrinkTo(size), nil
+}
+
+// Capacity returns priority queue size bound or Unbounded
+func (h *MaxPQ[T]) Capacity() int {
+	if h.size < 1 {
+		return Unbounded
+	}
+	return h.size
+}
+
+// SetCapacity changes priority queue size bound. Shrinking evicts items starting from the min value.
+// Evicted items are passed to eviction callback and returned in eviction order. Size should be positive or Unbounded
+func (h *MaxPQ[T]) SetCapacity(size int) ([]T, error) {
+	if err := checkSize(size); err != nil {
+		return nil, err
+	}
+	h.base().bounded(h.size)
+	h.size = size
+	return h.base().shrinkTo(size), nil
+}
+
+// shrinkTo evicts items until priority queue of size is not overfilled. Returns evicted items
+func (h *baseHeap[T]) shrinkTo(size int) []T {
+	if size < 1 || h.len() <= size {
+		return nil
+	}
+	evicted := make([]T, 0, h.len()-size)
+	for h.len() > size {
+		item := h.pop()
+		h.evicted(item)
+		evicted = append(evicted, item)
+	}
+	return evicted
+}
+
 // Slice return slice from base heap
 func (h *baseHeap[T]) slice() []T {
 	res := make([]T, 0, h.len())
@@ -403,12 +764,12 @@ func (h *baseHeap[T]) slice() []T {
 
 // Slice return slice from PQ
 func (h *MinPQ[T]) Slice() []T {
-	return h.slice()
+	return h.base().slice()
 }
 
 // Slice return slice from PQ
 func (h *MaxPQ[T]) Slice() []T {
-	return h.slice()
+	return h.base().slice()
 }
 
 // orderedSlicePQ return ordered slice from base heap
@@ -426,10 +787,10 @@ func (h *baseHeap[T]) orderedSlicePQ() []T {
 
 // OrderedSlice return ordered slice from PQ
 func (h *MinPQ[T]) OrderedSlice() []T {
-	return h.orderedSlicePQ()
+	return h.base().orderedSlicePQ()
 }
 
 // OrderedSlice return ordered slice from PQ
 func (h *MaxPQ[T]) OrderedSlice() []T {
-	return h.orderedSlicePQ()
+	return h.base().orderedSlicePQ()
 }
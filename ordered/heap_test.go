@@ -1,25 +1,42 @@
 package ordered
 
 import (
+	stdheap "container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/trezorg/heap"
+	"golang.org/x/exp/constraints"
 )
 
+func childIndexes[T constraints.Ordered](h *baseHeap[T], idx int) []int {
+	var res []int
+	first, last := h.children(idx)
+	for i := first; i < last; i++ {
+		res = append(res, i)
+	}
+	return res
+}
+
 func TestHeap2FactorChildren(t *testing.T) {
 	h, _ := NewMinHeap[int](2)
-	require.Equal(t, []int{1, 2}, h.children(0))
-	require.Equal(t, []int{3, 4}, h.children(1))
-	require.Equal(t, []int{5, 6}, h.children(2))
-	require.Equal(t, []int{7, 8}, h.children(3))
+	require.Equal(t, []int{1, 2}, childIndexes(&h.baseHeap, 0))
+	require.Equal(t, []int{3, 4}, childIndexes(&h.baseHeap, 1))
+	require.Equal(t, []int{5, 6}, childIndexes(&h.baseHeap, 2))
+	require.Equal(t, []int{7, 8}, childIndexes(&h.baseHeap, 3))
 }
 
 func TestHeap3FactorChildren(t *testing.T) {
 	h, _ := NewMinHeap[int](3)
-	require.Equal(t, []int{1, 2, 3}, h.children(0))
-	require.Equal(t, []int{4, 5, 6}, h.children(1))
-	require.Equal(t, []int{7, 8, 9}, h.children(2))
-	require.Equal(t, []int{10, 11, 12}, h.children(3))
+	require.Equal(t, []int{1, 2, 3}, childIndexes(&h.baseHeap, 0))
+	require.Equal(t, []int{4, 5, 6}, childIndexes(&h.baseHeap, 1))
+	require.Equal(t, []int{7, 8, 9}, childIndexes(&h.baseHeap, 2))
+	require.Equal(t, []int{10, 11, 12}, childIndexes(&h.baseHeap, 3))
 }
 
 func TestHeap2FactorParent(t *testing.T) {
@@ -557,3 +574,510 @@ func TestMaxHeap3BaseTypePush(t *testing.T) {
 	slice := h.Slice()
 	require.Equal(t, []value{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, slice)
 }
+
+func TestTryPopPick(t *testing.T) {
+	h, _ := NewMinHeap[int](3)
+	_, ok := h.TryPick()
+	require.False(t, ok)
+	_, ok = h.TryPop()
+	require.False(t, ok)
+
+	h.Push(2)
+	h.Push(1)
+	item, ok := h.TryPick()
+	require.True(t, ok)
+	require.Equal(t, 1, item)
+	item, ok = h.TryPop()
+	require.True(t, ok)
+	require.Equal(t, 1, item)
+	require.Equal(t, 1, h.Size())
+
+	pq, _ := NewMaxPQ[int](2)
+	_, ok = pq.TryPop()
+	require.False(t, ok)
+	pq.Heapify(1, 3, 2)
+	item, ok = pq.TryPop()
+	require.True(t, ok)
+	require.Equal(t, 2, item)
+}
+
+func TestSentinelErrors(t *testing.T) {
+	_, err := NewMinHeap[int](1)
+	require.True(t, errors.Is(err, ErrInvalidFactor))
+	_, err = NewMaxHeap[int](0)
+	require.True(t, errors.Is(err, ErrInvalidFactor))
+
+	h, _ := NewMinHeap[int](2)
+	require.PanicsWithValue(t, ErrEmpty, func() { h.Pop() })
+	require.PanicsWithValue(t, ErrEmpty, func() { h.Pick() })
+}
+
+func TestZeroValue(t *testing.T) {
+	var minH MinHeap[int]
+	minH.Heapify(3, 1, 2)
+	minH.Push(0)
+	require.Equal(t, 2, minH.factor)
+	require.Equal(t, []int{0, 1, 2, 3}, minH.Slice())
+
+	var maxH MaxHeap[int]
+	maxH.Push(1)
+	maxH.Push(3)
+	maxH.Push(2)
+	require.Equal(t, []int{3, 2, 1}, maxH.Slice())
+
+	var minP MinPQ[int]
+	minP.Push(2)
+	minP.Push(1)
+	minP.Push(3)
+	require.Equal(t, []int{1, 2, 3}, minP.OrderedSlice())
+
+	var maxP MaxPQ[int]
+	maxP.Heapify(2, 1, 3)
+	require.Equal(t, []int{3, 2, 1}, maxP.OrderedSlice())
+
+	type holder struct {
+		queue MinHeap[string]
+	}
+	var s holder
+	_, ok := s.queue.TryPop()
+	require.False(t, ok)
+	s.queue.Push("a")
+	require.Equal(t, "a", s.queue.Pop())
+}
+
+func TestCapacity(t *testing.T) {
+	h, _ := NewMinHeap[int](2, WithCapacity(10))
+	require.Equal(t, 10, h.Cap())
+
+	h.Reserve(20)
+	require.GreaterOrEqual(t, h.Cap(), 20)
+	for i := 5; i > 0; i-- {
+		h.Push(i)
+	}
+	h.Shrink()
+	require.Equal(t, 5, h.Cap())
+	require.Equal(t, 1, h.Pick())
+
+	h.Clear()
+	require.True(t, h.Empty())
+	require.Equal(t, 5, h.Cap())
+	require.Equal(t, []int{0, 0, 0, 0, 0}, h.items[:5])
+
+	h.Shrink()
+	require.Equal(t, 0, h.Cap())
+	h.Push(1)
+	require.Equal(t, 1, h.Pop())
+
+	pq, _ := NewMaxPQ[int](3, WithCapacity(3))
+	require.Equal(t, 3, pq.Cap())
+	pq.Heapify(1, 2, 3, 4)
+	pq.Clear()
+	require.True(t, pq.Empty())
+}
+
+func TestPopZeroesItems(t *testing.T) {
+	h, _ := NewMinHeap[string](2)
+	h.Push("a")
+	h.Push("b")
+	require.Equal(t, "a", h.Pop())
+	require.Equal(t, "", h.items[:2][1])
+}
+
+func TestHeapFactorsPopOrder(t *testing.T) {
+	for factor := 2; factor <= 6; factor++ {
+		items := rand.Perm(500)
+		minH, _ := NewMinHeap[int](factor)
+		maxH, _ := NewMaxHeap[int](factor)
+		for _, item := range items {
+			minH.Push(item)
+			maxH.Push(item)
+		}
+		sort.Ints(items)
+		require.Equal(t, items, minH.Slice(), "factor %d", factor)
+		sort.Sort(sort.Reverse(sort.IntSlice(items)))
+		require.Equal(t, items, maxH.Slice(), "factor %d", factor)
+	}
+}
+
+func TestPopAllocations(t *testing.T) {
+	for _, factor := range []int{2, 3, 4} {
+		h, _ := NewMinHeap[int](factor, WithCapacity(1000))
+		h.Heapify(rand.Perm(1000)...)
+		allocs := testing.AllocsPerRun(100, func() {
+			h.Push(h.Pop())
+		})
+		require.Zero(t, allocs, "factor %d", factor)
+	}
+}
+
+func TestBottomUpPopOrder(t *testing.T) {
+	for factor := 2; factor <= 6; factor++ {
+		items := rand.Perm(500)
+		minH, _ := NewMinHeap[int](factor, WithBottomUp())
+		maxH, _ := NewMaxHeap[int](factor, WithBottomUp())
+		minH.Heapify(append([]int(nil), items...)...)
+		for _, item := range items {
+			maxH.Push(item)
+		}
+		sort.Ints(items)
+		require.Equal(t, items, minH.Slice(), "factor %d", factor)
+		sort.Sort(sort.Reverse(sort.IntSlice(items)))
+		require.Equal(t, items, maxH.Slice(), "factor %d", factor)
+	}
+
+	pq, _ := NewMinPQ[int](5, WithBottomUp())
+	pq.Heapify(4, 2, 3, 1, 6, 5, 7, 9, 8, 10)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, pq.OrderedSlice())
+}
+
+func TestCommonInterfaces(t *testing.T) {
+	minH, _ := NewMinHeap[int](3)
+	blockH, _ := NewBlockMinHeap[int](8)
+	maxP, _ := NewMaxPQ[int](3)
+	heaps := []heap.Heap[int]{&minH, &blockH, &maxP}
+	for _, h := range heaps {
+		h.Heapify(5, 1, 4)
+		h.Push(2)
+	}
+	require.Equal(t, 1, heaps[0].Pick())
+	require.Equal(t, 4, heaps[0].Size())
+	require.Equal(t, 1, heaps[1].Pick())
+	require.Equal(t, 4, heaps[1].Size())
+	require.Equal(t, 2, heaps[2].Pick())
+	require.Equal(t, 3, heaps[2].Size())
+
+	minP, _ := NewMinPQ[int](2)
+	var zeroP MaxPQ[int]
+	queues := []heap.BoundedQueue[int]{&minP, &zeroP}
+	for _, q := range queues {
+		q.Heapify(3, 1, 2)
+	}
+	require.Equal(t, 2, queues[0].Capacity())
+	require.Equal(t, []int{1, 2}, queues[0].OrderedSlice())
+	require.Equal(t, Unbounded, queues[1].Capacity())
+	require.Equal(t, []int{3, 2, 1}, queues[1].OrderedSlice())
+}
+
+func TestContainer(t *testing.T) {
+	h, _ := NewMinHeap[int](2)
+	h.Heapify(5, 3, 8)
+	c, err := h.Container()
+	require.NoError(t, err)
+
+	stdheap.Push(c, 1)
+	h.Push(4)
+	require.Equal(t, 5, c.Len())
+	require.Equal(t, 1, stdheap.Pop(c))
+	require.Equal(t, 3, h.Pop())
+	require.Equal(t, []int{4, 5, 8}, h.Slice())
+
+	pq, _ := NewMaxPQ[int](2)
+	pq.Heapify(1, 2, 3)
+	c, _ = pq.Container()
+	require.Equal(t, 2, stdheap.Pop(c))
+	require.Equal(t, 3, pq.Pop())
+
+	h3, _ := NewMinHeap[int](3)
+	_, err = h3.Container()
+	require.True(t, errors.Is(err, ErrInvalidFactor))
+}
+
+func TestNaNPolicy(t *testing.T) {
+	nan := math.NaN()
+	items := func() []float64 { return []float64{3, nan, 1, nan, 2, 0} }
+	fill := func(h interface{ Push(float64) }) {
+		for _, item := range items() {
+			h.Push(item)
+		}
+	}
+
+	minH, _ := NewMinHeap[float64](2, WithNaN(NaNFirst))
+	fill(&minH)
+	require.Equal(t, "[NaN NaN 0 1 2 3]", fmt.Sprint(minH.Slice()))
+	minH, _ = NewMinHeap[float64](3, WithNaN(NaNLast))
+	minH.Heapify(items()...)
+	require.Equal(t, "[0 1 2 3 NaN NaN]", fmt.Sprint(minH.Slice()))
+	maxH, _ := NewMaxHeap[float64](2, WithNaN(NaNFirst), WithBottomUp())
+	maxH.Heapify(items()...)
+	require.Equal(t, "[NaN NaN 3 2 1 0]", fmt.Sprint(maxH.Slice()))
+
+	minPQ, _ := NewMinPQ[float64](3, WithNaN(NaNFirst))
+	fill(&minPQ)
+	require.Equal(t, "[NaN NaN 0]", fmt.Sprint(minPQ.OrderedSlice()))
+	minPQ, _ = NewMinPQ[float64](3, WithNaN(NaNLast))
+	minPQ.Heapify(items()...)
+	require.Equal(t, "[0 1 2]", fmt.Sprint(minPQ.OrderedSlice()))
+	maxPQ, _ := NewMaxPQ[float64](3, WithNaN(NaNFirst))
+	maxPQ.Heapify(items()...)
+	require.Equal(t, "[NaN NaN 3]", fmt.Sprint(maxPQ.OrderedSlice()))
+	maxPQ, _ = NewMaxPQ[float64](3, WithNaN(NaNLast))
+	fill(&maxPQ)
+	require.Equal(t, "[3 2 1]", fmt.Sprint(maxPQ.OrderedSlice()))
+}
+
+func TestNaNReject(t *testing.T) {
+	nan := math.NaN()
+	h, _ := NewMinHeap[float64](2, WithNaN(NaNReject))
+	require.NoError(t, h.TryPush(1))
+	require.True(t, errors.Is(h.TryPush(nan), ErrNaN))
+	require.PanicsWithValue(t, ErrNaN, func() { h.Push(nan) })
+	require.True(t, errors.Is(h.TryHeapify(2, nan), ErrNaN))
+	require.PanicsWithValue(t, ErrNaN, func() { h.Heapify(2, nan) })
+	require.Equal(t, []float64{1}, h.Slice())
+
+	pq, _ := NewMaxPQ[float64](2, WithNaN(NaNReject))
+	require.NoError(t, pq.TryHeapify(1, 2, 3))
+	require.True(t, errors.Is(pq.TryPush(nan), ErrNaN))
+	require.True(t, errors.Is(pq.TryHeapify(nan, 1, 2), ErrNaN))
+	require.PanicsWithValue(t, ErrNaN, func() { pq.Push(nan) })
+	require.Equal(t, []float64{3, 2}, pq.OrderedSlice())
+
+	var strings MinHeap[string]
+	require.NoError(t, strings.TryPush("a"))
+}
+
+func TestNaNPolicyBypass(t *testing.T) {
+	nan := math.NaN()
+	block, err := NewBlockMinHeap[float64](4, WithNaN(NaNLast))
+	require.NoError(t, err)
+	block.Heapify(2, nan, 1, 3, nan, 0)
+	var popped []float64
+	for !block.Empty() {
+		popped = append(popped, block.Pop())
+	}
+	require.Equal(t, "[0 1 2 3 NaN NaN]", fmt.Sprint(popped))
+	_, err = NewBlockMaxHeap[float64](4, WithCapacity(8))
+	require.True(t, errors.Is(err, ErrUnsupportedOption))
+	rejecting, _ := NewBlockMaxHeap[float64](4, WithNaN(NaNReject))
+	require.PanicsWithValue(t, ErrNaN, func() { rejecting.Push(nan) })
+	require.PanicsWithValue(t, ErrNaN, func() { rejecting.Heapify(1, nan) })
+
+	keyed, err := NewKeyedMinHeap[float64, string](2, WithNaN(NaNFirst))
+	require.NoError(t, err)
+	keyed.Push(1, "a")
+	keyed.Push(nan, "b")
+	keyed.Push(0, "c")
+	_, value := keyed.Pop()
+	require.Equal(t, "b", value)
+	_, err = NewKeyedMaxHeap[float64, string](2, WithBottomUp())
+	require.True(t, errors.Is(err, ErrUnsupportedOption))
+
+	by, err := NewMaxHeapBy(2, func(item []float64) float64 { return item[0] }, WithNaN(NaNReject))
+	require.NoError(t, err)
+	by.Push([]float64{1})
+	require.PanicsWithValue(t, ErrNaN, func() { by.Push([]float64{nan}) })
+	_, err = NewMinHeapBy(2, func(item []float64) float64 { return item[0] }, WithCapacity(4))
+	require.True(t, errors.Is(err, ErrUnsupportedOption))
+
+	weighted, _ := NewWeightedMinPQ(10, func(item float64) int { return 1 }, WithNaN(NaNReject))
+	require.PanicsWithValue(t, ErrNaN, func() { weighted.Push(nan) })
+
+	h, _ := NewMinHeap[float64](2, WithNaN(NaNReject))
+	c, err := h.Container()
+	require.NoError(t, err)
+	require.PanicsWithValue(t, ErrNaN, func() { c.Push(nan) })
+	require.Equal(t, 0, h.Size())
+
+	s := []float64{2, nan, 1, nan, 0}
+	Init(s, 2)
+	require.True(t, IsHeap(s, 2))
+	item, s := PopSlice(s, 2)
+	require.True(t, math.IsNaN(item))
+	s = []float64{2, nan, 1, nan, 0}
+	InitMax(s, 2)
+	require.True(t, IsHeapMax(s, 2))
+	item, _ = PopSliceMax(s, 2)
+	require.Equal(t, 2.0, item)
+}
+
+func TestPushEvict(t *testing.T) {
+	var evicted []int
+	pq, err := NewMinPQ[int](3, WithEvict(func(item int) { evicted = append(evicted, item) }))
+	require.NoError(t, err)
+	pq.Heapify(5, 1, 4, 2)
+	require.Equal(t, []int{5}, evicted)
+
+	item, ok := pq.PushEvict(3)
+	require.True(t, ok)
+	require.Equal(t, 4, item)
+	item, ok = pq.PushEvict(7)
+	require.True(t, ok)
+	require.Equal(t, 7, item)
+	pq.Push(0)
+	require.Equal(t, []int{5, 4, 7, 3}, evicted)
+	require.Equal(t, []int{0, 1, 2}, pq.OrderedSlice())
+
+	_, ok = pq.PushEvict(1)
+	require.False(t, ok)
+
+	var maxPQ MaxPQ[int]
+	_, ok = maxPQ.PushEvict(1)
+	require.False(t, ok)
+	maxPQ.OnEvict(func(item int) { evicted = append(evicted, item) })
+	maxPQ.Push(2)
+	require.Len(t, evicted, 4)
+
+	_, err = NewMaxPQ[int](3, WithEvict(func(item string) {}))
+	require.Error(t, err)
+	_, err = NewMaxHeap[int](2, WithEvict(func(item int) {}))
+	require.True(t, errors.Is(err, ErrUnsupportedOption))
+	_, err = NewBlockMinHeap[int](4, WithEvict(func(item int) {}))
+	require.True(t, errors.Is(err, ErrUnsupportedOption))
+}
+
+func TestSetCapacity(t *testing.T) {
+	pq, err := NewMinPQ[int](Unbounded)
+	require.NoError(t, err)
+	require.Equal(t, Unbounded, pq.Capacity())
+	pq.Heapify(rand.Perm(10)...)
+	require.Equal(t, 10, pq.Size())
+	var callback []int
+	pq.OnEvict(func(item int) { callback = append(callback, item) })
+
+	evicted, err := pq.SetCapacity(6)
+	require.NoError(t, err)
+	require.Equal(t, []int{9, 8, 7, 6}, evicted)
+	require.Equal(t, evicted, callback)
+	require.Equal(t, 6, pq.Capacity())
+	pq.Push(-1)
+	require.Equal(t, 6, pq.Size())
+
+	evicted, err = pq.SetCapacity(10)
+	require.NoError(t, err)
+	require.Empty(t, evicted)
+	pq.Push(-2)
+	require.Equal(t, 7, pq.Size())
+
+	_, err = pq.SetCapacity(0)
+	require.True(t, errors.Is(err, ErrInvalidSize))
+	require.Equal(t, 10, pq.Capacity())
+	evicted, err = pq.SetCapacity(Unbounded)
+	require.NoError(t, err)
+	require.Empty(t, evicted)
+
+	var maxPQ MaxPQ[int]
+	maxPQ.Heapify(1, 2, 3)
+	evicted, err = maxPQ.SetCapacity(1)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, evicted)
+	require.Equal(t, []int{3}, maxPQ.OrderedSlice())
+
+	for _, size := range []int{0, -2} {
+		_, err = NewMinPQ[int](size)
+		require.True(t, errors.Is(err, ErrInvalidSize))
+		_, err = NewMaxPQ[int](size)
+		require.True(t, errors.Is(err, ErrInvalidSize))
+	}
+}
+
+func TestPushMany(t *testing.T) {
+	for _, batch := range []int{1, 10, 1000} {
+		items := rand.Perm(2000)
+		h, _ := NewMinHeap[int](3)
+		h.Heapify(append([]int(nil), items[:1000]...)...)
+		for i := 1000; i < len(items); i += batch {
+			h.PushMany(items[i : i+batch]...)
+		}
+		require.Equal(t, len(items), h.Size())
+		require.Equal(t, []int{0, 1, 2}, h.PopN(3))
+		require.Len(t, h.PopN(5000), len(items)-3)
+		require.Empty(t, h.PopN(1))
+	}
+
+	pq, _ := NewMaxPQ[int](5)
+	pq.Push(3)
+	pq.PushMany(1, 9, 4, 7, 2, 8, 5)
+	require.Equal(t, 5, pq.Size())
+	require.Equal(t, []int{4, 5}, pq.PopN(2))
+	require.Equal(t, []int{9, 8, 7}, pq.OrderedSlice())
+}
+
+func TestPushPopReplace(t *testing.T) {
+	h, _ := NewMinHeap[int](2)
+	require.Equal(t, 5, h.PushPop(5))
+	require.True(t, h.Empty())
+	require.PanicsWithValue(t, ErrEmpty, func() { h.Replace(1) })
+
+	h.Heapify(3, 6, 4)
+	require.Equal(t, 2, h.PushPop(2))
+	require.Equal(t, 3, h.PushPop(5))
+	require.Equal(t, 4, h.Replace(1))
+	require.Equal(t, []int{1, 5, 6}, h.Slice())
+
+	maxH, _ := NewMaxHeap[int](4)
+	maxH.Heapify(3, 6, 4)
+	require.Equal(t, 6, maxH.PushPop(5))
+	require.Equal(t, 5, maxH.Replace(1))
+	require.Equal(t, []int{4, 3, 1}, maxH.Slice())
+}
+
+func TestRemoveIf(t *testing.T) {
+	even := func(item int) bool { return item%2 == 0 }
+	items := rand.Perm(1000)
+
+	h, _ := NewMinHeap[int](3)
+	h.Heapify(append([]int(nil), items...)...)
+	require.Equal(t, 500, h.RemoveIf(even))
+	require.Equal(t, 0, h.RemoveIf(even))
+	require.Equal(t, 500, h.Size())
+	require.Equal(t, []int{1, 3, 5}, h.PopN(3))
+
+	maxH, _ := NewMaxHeap[int](2)
+	maxH.Heapify(append([]int(nil), items...)...)
+	require.Equal(t, 500, maxH.Retain(even))
+	require.Equal(t, []int{998, 996}, maxH.PopN(2))
+
+	pq, _ := NewMinPQ[int](10)
+	pq.Heapify(append([]int(nil), items...)...)
+	extracted := pq.Extract(even)
+	sort.Ints(extracted)
+	require.Equal(t, []int{0, 2, 4, 6, 8}, extracted)
+	require.Equal(t, []int{1, 3, 5, 7, 9}, pq.OrderedSlice())
+	require.Empty(t, pq.Extract(even))
+}
+
+func TestClone(t *testing.T) {
+	h, _ := NewMinHeap[int](3, WithCapacity(10))
+	h.Heapify(5, 1, 4)
+	c := h.Clone()
+	c.Push(0)
+	require.Equal(t, 1, h.Pick())
+	require.Equal(t, []int{0, 1, 4, 5}, c.Slice())
+	require.Equal(t, []int{1, 4, 5}, h.Slice())
+
+	pq, _ := NewMaxPQ[int](2)
+	pq.Heapify(1, 2, 3)
+	cpq := pq.CloneFunc(func(item int) int { return item * 10 })
+	cpq.Push(25)
+	require.Equal(t, []int{30, 25}, cpq.OrderedSlice())
+	require.Equal(t, []int{3, 2}, pq.OrderedSlice())
+
+	block, _ := NewBlockMaxHeap[int](4)
+	block.Heapify(rand.Perm(100)...)
+	cblock := block.Clone()
+	require.Equal(t, 99, block.Pop())
+	require.Equal(t, 99, cblock.Pick())
+	require.Equal(t, 100, cblock.Size())
+
+	keyed, _ := NewKeyedMinHeap[int, string](2)
+	keyed.Heapify([]int{2, 1}, []string{"b", "a"})
+	ckeyed := keyed.CloneFunc(func(value string) string { return value + value })
+	keyed.Pop()
+	key, value := ckeyed.Pick()
+	require.Equal(t, 1, key)
+	require.Equal(t, "aa", value)
+
+	unique, _ := NewUniqueMinPQ(3, lastDigit)
+	unique.Heapify(1, 2, 3)
+	cunique := unique.Clone()
+	cunique.Remove(2)
+	require.True(t, unique.Contains(2))
+	require.Equal(t, []int{1, 3}, cunique.OrderedSlice())
+
+	weighted, _ := NewWeightedMinPQ(3, func(item int) int { return 1 })
+	weighted.Heapify(1, 2, 3)
+	cweighted := weighted.Clone()
+	cweighted.Pop()
+	require.Equal(t, 3, weighted.Weight())
+	require.Equal(t, 2, cweighted.Weight())
+}
@@ -0,0 +1,483 @@
+package ordered
+
+import (
+	"fmt"
+
+	"github.com/trezorg/heap"
+)
+
+// validateItems checks that none of n items of heap of factor is ordered before its parent.
+// before reports whether item at level order index i is ordered before item at index j
+func validateItems(n, factor int, before func(i, j int) bool) error {
+	for i := 1; i < n; i++ {
+		if p := parent(i, factor); before(i, p) {
+			return &heap.InvariantError{Parent: p, Child: i}
+		}
+	}
+	return nil
+}
+
+// heapHeight returns number of levels of heap of factor with n items
+func heapHeight(n, factor int) int {
+	res := 0
+	for width := 1; n > 0; width *= factor {
+		n -= width
+		res++
+	}
+	return res
+}
+
+// groupLevels returns n items of heap of factor grouped by levels, get returns item at level order index
+func groupLevels[T any](n, factor int, get func(i int) T) [][]T {
+	res := make([][]T, 0, heapHeight(n, factor))
+	for start, width := 0, 1; start < n; start, width = start+width, width*factor {
+		level := make([]T, min(width, n-start))
+		for i := range level {
+			level[i] = get(start + i)
+		}
+		res = append(res, level)
+	}
+	return res
+}
+
+func (h *baseHeap[T]) validate() error {
+	return validateItems(h.len(), h.factor, func(i, j int) bool {
+		return h.check(h.at(i), h.at(j))
+	})
+}
+
+func (h *baseHeap[T]) height() int {
+	return heapHeight(h.len(), h.factor)
+}
+
+func (h *baseHeap[T]) levelOrder() [][]T {
+	return groupLevels(h.len(), h.factor, h.at)
+}
+
+// validate checks heap property and total weight of items
+func (h *baseWeightedPQ[T]) validate() error {
+	if err := h.baseHeap.validate(); err != nil {
+		return err
+	}
+	if h.sizer == nil {
+		return nil
+	}
+	weight := 0
+	for _, item := range h.items {
+		weight += h.sizer(item)
+	}
+	if weight != h.weight {
+		return fmt.Errorf("weight %d differs from total weight of items %d", h.weight, weight)
+	}
+	return nil
+}
+
+// validate checks heap property and that keys of items are not changed and indexed at their positions
+func (h *baseUniqueHeap[T, K]) validate() error {
+	if err := h.baseHeap.validate(); err != nil {
+		return err
+	}
+	for i, item := range h.items {
+		key := h.key(item)
+		if key != h.keys[i] {
+			return fmt.Errorf("key of item %d changed after insertion", i)
+		}
+		if idx, ok := h.index[key]; !ok || idx != i {
+			return fmt.Errorf("key of item %d is not indexed at its position", i)
+		}
+	}
+	return nil
+}
+
+// at returns item at level order index i
+func (h *baseBlockHeap[T]) at(i int) T {
+	return h.items[h.position(i+1)]
+}
+
+func (h *baseBlockHeap[T]) validate() error {
+	return validateItems(h.size, 2, func(i, j int) bool {
+		return h.check(h.at(i), h.at(j))
+	})
+}
+
+func (h *baseBlockHeap[T]) height() int {
+	return heapHeight(h.size, 2)
+}
+
+func (h *baseBlockHeap[T]) levelOrder() [][]T {
+	return groupLevels(h.size, 2, h.at)
+}
+
+func (h *baseKeyedHeap[K, V]) validate() error {
+	return validateItems(len(h.keys), h.factor, func(i, j int) bool {
+		return h.check(h.keys[i], h.keys[j])
+	})
+}
+
+func (h *baseKeyedHeap[K, V]) height() int {
+	return heapHeight(len(h.keys), h.factor)
+}
+
+func (h *baseKeyedHeap[K, V]) levelOrder() [][]K {
+	return groupLevels(len(h.keys), h.factor, func(i int) K {
+		return h.keys[i]
+	})
+}
+
+// validateKeys checks that cached keys of values are not changed since insertion
+func (h *baseKeyedHeap[K, V]) validateKeys(key func(V) K) error {
+	for i, cached := range h.keys {
+		if k := key(h.values[h.refs[i]]); k != cached && !(isNaN(k) && isNaN(cached)) {
+			return fmt.Errorf("key of item %d changed after insertion", i)
+		}
+	}
+	return nil
+}
+
+func (h *baseKeyedHeap[K, V]) valueLevels() [][]V {
+	return groupLevels(len(h.keys), h.factor, func(i int) V {
+		return h.values[h.refs[i]]
+	})
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *MinHeap[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *MaxHeap[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *MinPQ[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *MaxPQ[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items and their total weight. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *WeightedMinPQ[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items and their total weight. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *WeightedMaxPQ[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items and their keys index. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *UniqueMinHeap[T, K]) Validate() error {
+	return h.validate()
+}
+
+// Validate checks heap property of all items and their keys index. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *UniqueMaxHeap[T, K]) Validate() error {
+	return h.validate()
+}
+
+// Validate checks heap property of all items and their keys index. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *UniqueMinPQ[T, K]) Validate() error {
+	return h.validate()
+}
+
+// Validate checks heap property of all items and their keys index. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *UniqueMaxPQ[T, K]) Validate() error {
+	return h.validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *BlockMinHeap[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *BlockMaxHeap[T]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all keys. Returns *heap.InvariantError
+// with level order indexes of the first key ordered before its parent
+func (h *KeyedMinHeap[K, V]) Validate() error {
+	return h.base().validate()
+}
+
+// Validate checks heap property of all keys. Returns *heap.InvariantError
+// with level order indexes of the first key ordered before its parent
+func (h *KeyedMaxHeap[K, V]) Validate() error {
+	return h.base().validate()
+}
+
+// Height returns number of heap levels
+func (h *MinHeap[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *MaxHeap[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *MinPQ[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *MaxPQ[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *WeightedMinPQ[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *WeightedMaxPQ[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *UniqueMinHeap[T, K]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *UniqueMaxHeap[T, K]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *UniqueMinPQ[T, K]) Height() int {
+	return h.height()
+}
+
+// Height returns number of priority queue levels
+func (h *UniqueMaxPQ[T, K]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *BlockMinHeap[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *BlockMaxHeap[T]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *KeyedMinHeap[K, V]) Height() int {
+	return h.height()
+}
+
+// Height returns number of heap levels
+func (h *KeyedMaxHeap[K, V]) Height() int {
+	return h.height()
+}
+
+// Factor returns heap factor
+func (h *MinHeap[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns heap factor
+func (h *MaxHeap[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns priority queue factor
+func (h *MinPQ[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns priority queue factor
+func (h *MaxPQ[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns priority queue factor
+func (h *WeightedMinPQ[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns priority queue factor
+func (h *WeightedMaxPQ[T]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns heap factor
+func (h *UniqueMinHeap[T, K]) Factor() int {
+	return h.factor
+}
+
+// Factor returns heap factor
+func (h *UniqueMaxHeap[T, K]) Factor() int {
+	return h.factor
+}
+
+// Factor returns priority queue factor
+func (h *UniqueMinPQ[T, K]) Factor() int {
+	return h.factor
+}
+
+// Factor returns priority queue factor
+func (h *UniqueMaxPQ[T, K]) Factor() int {
+	return h.factor
+}
+
+// Factor returns heap factor, block heap is binary
+func (h *BlockMinHeap[T]) Factor() int {
+	return 2
+}
+
+// Factor returns heap factor, block heap is binary
+func (h *BlockMaxHeap[T]) Factor() int {
+	return 2
+}
+
+// Factor returns heap factor
+func (h *KeyedMinHeap[K, V]) Factor() int {
+	return h.base().factor
+}
+
+// Factor returns heap factor
+func (h *KeyedMaxHeap[K, V]) Factor() int {
+	return h.base().factor
+}
+
+// Levels returns items grouped by heap levels starting from the min value
+func (h *MinHeap[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by heap levels starting from the max value
+func (h *MaxHeap[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the max value evicted first
+func (h *MinPQ[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the min value evicted first
+func (h *MaxPQ[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the max value evicted first
+func (h *WeightedMinPQ[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the min value evicted first
+func (h *WeightedMaxPQ[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by heap levels starting from the min value
+func (h *UniqueMinHeap[T, K]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by heap levels starting from the max value
+func (h *UniqueMaxHeap[T, K]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the max value evicted first
+func (h *UniqueMinPQ[T, K]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by priority queue levels starting from the min value evicted first
+func (h *UniqueMaxPQ[T, K]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by heap levels starting from the min value
+func (h *BlockMinHeap[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns items grouped by heap levels starting from the max value
+func (h *BlockMaxHeap[T]) Levels() [][]T {
+	return h.levelOrder()
+}
+
+// Levels returns keys grouped by heap levels starting from the min key
+func (h *KeyedMinHeap[K, V]) Levels() [][]K {
+	return h.levelOrder()
+}
+
+// Levels returns keys grouped by heap levels starting from the max key
+func (h *KeyedMaxHeap[K, V]) Levels() [][]K {
+	return h.levelOrder()
+}
+
+// Validate checks heap property of all items and that their keys are not changed since insertion.
+// Returns *heap.InvariantError with level order indexes of the first item ordered before its parent
+func (h *MinHeapBy[T, K]) Validate() error {
+	if err := h.keyed.Validate(); err != nil {
+		return err
+	}
+	return h.keyed.validateKeys(h.key)
+}
+
+// Height returns number of heap levels
+func (h *MinHeapBy[T, K]) Height() int {
+	return h.keyed.Height()
+}
+
+// Factor returns heap factor
+func (h *MinHeapBy[T, K]) Factor() int {
+	return h.keyed.Factor()
+}
+
+// Levels returns items grouped by heap levels starting from the item with min key
+func (h *MinHeapBy[T, K]) Levels() [][]T {
+	return h.keyed.valueLevels()
+}
+
+// Validate checks heap property of all items and that their keys are not changed since insertion.
+// Returns *heap.InvariantError with level order indexes of the first item ordered before its parent
+func (h *MaxHeapBy[T, K]) Validate() error {
+	if err := h.keyed.Validate(); err != nil {
+		return err
+	}
+	return h.keyed.validateKeys(h.key)
+}
+
+// Height returns number of heap levels
+func (h *MaxHeapBy[T, K]) Height() int {
+	return h.keyed.Height()
+}
+
+// Factor returns heap factor
+func (h *MaxHeapBy[T, K]) Factor() int {
+	return h.keyed.Factor()
+}
+
+// Levels returns items grouped by heap levels starting from the item with max key
+func (h *MaxHeapBy[T, K]) Levels() [][]T {
+	return h.keyed.valueLevels()
+}
@@ -0,0 +1,86 @@
+package ordered
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trezorg/heap"
+)
+
+// requireViolation checks that err reports item child ordered before its parent
+func requireViolation(t *testing.T, err error, parent, child int) {
+	var invariant *heap.InvariantError
+	require.True(t, errors.As(err, &invariant))
+	require.Equal(t, heap.InvariantError{Parent: parent, Child: child}, *invariant)
+}
+
+func TestValidate(t *testing.T) {
+	h, _ := NewMinHeap[int](3)
+	h.Heapify(rand.Perm(20)...)
+	require.NoError(t, h.Validate())
+	require.Equal(t, 3, h.Factor())
+	require.Equal(t, 4, h.Height())
+	levels := h.Levels()
+	require.Len(t, levels, 4)
+	require.Equal(t, []int{0}, levels[0])
+	require.Len(t, levels[1], 3)
+	require.Len(t, levels[3], 7)
+	levels[0][0] = 100
+	require.Equal(t, 0, h.Pick())
+
+	h.items[7] = -1
+	requireViolation(t, h.Validate(), 2, 7)
+
+	var zero MaxHeap[int]
+	require.NoError(t, zero.Validate())
+	require.Equal(t, 2, zero.Factor())
+	require.Equal(t, 0, zero.Height())
+	require.Empty(t, zero.Levels())
+
+	pq, _ := NewMaxPQ[int](3)
+	pq.Heapify(5, 1, 4, 2)
+	require.NoError(t, pq.Validate())
+	require.Equal(t, [][]int{{2}, {5, 4}}, pq.Levels())
+}
+
+func TestValidateBlockHeap(t *testing.T) {
+	h, _ := NewBlockMaxHeap[int](4)
+	h.Heapify(rand.Perm(100)...)
+	require.NoError(t, h.Validate())
+	require.Equal(t, 2, h.Factor())
+	require.Equal(t, 7, h.Height())
+	levels := h.Levels()
+	require.Equal(t, []int{99}, levels[0])
+	require.Equal(t, 100, len(slices.Concat(levels...)))
+
+	h.items[h.position(42)] = 1000
+	requireViolation(t, h.Validate(), 20, 41)
+}
+
+func TestValidateKeys(t *testing.T) {
+	type task struct {
+		priority int
+	}
+	h, _ := NewMinHeapBy(2, func(item *task) int { return item.priority })
+	first, second := &task{1}, &task{2}
+	h.Heapify(second, first)
+	require.NoError(t, h.Validate())
+	require.Equal(t, [][]*task{{first}, {second}}, h.Levels())
+	first.priority = 3
+	require.Error(t, h.Validate())
+
+	unique, _ := NewUniqueMinHeap(2, lastDigit)
+	unique.Heapify(11, 2, 3)
+	require.NoError(t, unique.Validate())
+	unique.items[1] = 12
+	require.Error(t, unique.Validate())
+
+	weighted, _ := NewWeightedMaxPQ(10, func(item int) int { return item })
+	weighted.Heapify(1, 2, 3)
+	require.NoError(t, weighted.Validate())
+	weighted.weight++
+	require.Error(t, weighted.Validate())
+}
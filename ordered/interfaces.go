@@ -0,0 +1,40 @@
+package ordered
+
+import (
+	"github.com/trezorg/heap"
+	"golang.org/x/exp/constraints"
+)
+
+// checks that heaps and priority queues implement common interfaces
+func _[T constraints.Ordered]() {
+	var _ heap.Heap[T] = (*MinHeap[T])(nil)
+	var _ heap.Heap[T] = (*MaxHeap[T])(nil)
+	var _ heap.BoundedQueue[T] = (*MinPQ[T])(nil)
+	var _ heap.BoundedQueue[T] = (*MaxPQ[T])(nil)
+	var _ heap.Heap[T] = (*BlockMinHeap[T])(nil)
+	var _ heap.Heap[T] = (*BlockMaxHeap[T])(nil)
+	var _ heap.Heap[T] = (*WeightedMinPQ[T])(nil)
+	var _ heap.Heap[T] = (*WeightedMaxPQ[T])(nil)
+	var _ heap.Heap[T] = (*UniqueMinHeap[T, T])(nil)
+	var _ heap.Heap[T] = (*UniqueMaxHeap[T, T])(nil)
+	var _ heap.BoundedQueue[T] = (*UniqueMinPQ[T, T])(nil)
+	var _ heap.BoundedQueue[T] = (*UniqueMaxPQ[T, T])(nil)
+	var _ heap.Heap[T] = (*MinHeapBy[T, T])(nil)
+	var _ heap.Heap[T] = (*MaxHeapBy[T, T])(nil)
+	var _ heap.Inspector[T] = (*MinHeap[T])(nil)
+	var _ heap.Inspector[T] = (*MaxHeap[T])(nil)
+	var _ heap.Inspector[T] = (*MinPQ[T])(nil)
+	var _ heap.Inspector[T] = (*MaxPQ[T])(nil)
+	var _ heap.Inspector[T] = (*BlockMinHeap[T])(nil)
+	var _ heap.Inspector[T] = (*BlockMaxHeap[T])(nil)
+	var _ heap.Inspector[T] = (*WeightedMinPQ[T])(nil)
+	var _ heap.Inspector[T] = (*WeightedMaxPQ[T])(nil)
+	var _ heap.Inspector[T] = (*UniqueMinHeap[T, T])(nil)
+	var _ heap.Inspector[T] = (*UniqueMaxHeap[T, T])(nil)
+	var _ heap.Inspector[T] = (*UniqueMinPQ[T, T])(nil)
+	var _ heap.Inspector[T] = (*UniqueMaxPQ[T, T])(nil)
+	var _ heap.Inspector[T] = (*MinHeapBy[T, T])(nil)
+	var _ heap.Inspector[T] = (*MaxHeapBy[T, T])(nil)
+	var _ heap.Inspector[T] = (*KeyedMinHeap[T, T])(nil)
+	var _ heap.Inspector[T] = (*KeyedMaxHeap[T, T])(nil)
+}
@@ -0,0 +1,307 @@
+package ordered
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// baseKeyedHeap heap structure keeping keys and payloads in separate slices.
+// Sifts move only keys and indexes of payloads, payloads stay in place until pop
+type baseKeyedHeap[K constraints.Ordered, V any] struct {
+	check     func(item1 K, item2 K) bool
+	keys      []K
+	refs      []int
+	values    []V
+	free      []int
+	factor    int
+	rejectNaN bool
+}
+
+// KeyedMinHeap is heap of key, value pairs that returns pair with min key.
+// Zero value is empty heap with factor 2
+type KeyedMinHeap[K constraints.Ordered, V any] struct {
+	baseKeyedHeap[K, V]
+}
+
+// KeyedMaxHeap is heap of key, value pairs that returns pair with max key.
+// Zero value is empty heap with factor 2
+type KeyedMaxHeap[K constraints.Ordered, V any] struct {
+	baseKeyedHeap[K, V]
+}
+
+func newKeyedHeap[K constraints.Ordered, V any](
+	factor int,
+	check func(item1 K, item2 K) bool,
+	opts []Option,
+) (baseKeyedHeap[K, V], error) {
+	if err := checkFactor(factor); err != nil {
+		return baseKeyedHeap[K, V]{}, err
+	}
+	o := newOptions(opts)
+	if err := o.nanOnly(); err != nil {
+		return baseKeyedHeap[K, V]{}, err
+	}
+	check, rejectNaN := nanPolicy(check, o.nan, false)
+	return baseKeyedHeap[K, V]{
+		check:     check,
+		factor:    factor,
+		rejectNaN: rejectNaN,
+	}, nil
+}
+
+// NewKeyedMinHeap heap constructor. WithNaN is the only supported option, it applies to keys
+func NewKeyedMinHeap[K constraints.Ordered, V any](factor int, opts ...Option) (KeyedMinHeap[K, V], error) {
+	baseHeap, err := newKeyedHeap[K, V](factor, minCheck[K], opts)
+	if err != nil {
+		return KeyedMinHeap[K, V]{}, err
+	}
+	return KeyedMinHeap[K, V]{baseHeap}, nil
+}
+
+// NewKeyedMaxHeap heap constructor. WithNaN is the only supported option, it applies to keys
+func NewKeyedMaxHeap[K constraints.Ordered, V any](factor int, opts ...Option) (KeyedMaxHeap[K, V], error) {
+	baseHeap, err := newKeyedHeap[K, V](factor, maxCheck[K], opts)
+	if err != nil {
+		return KeyedMaxHeap[K, V]{}, err
+	}
+	return KeyedMaxHeap[K, V]{baseHeap}, nil
+}
+
+// init makes zero value heap usable with default factor
+func (h *baseKeyedHeap[K, V]) init(check func(item1 K, item2 K) bool) {
+	h.factor = defaultFactor
+	h.check = check
+}
+
+func (h *KeyedMinHeap[K, V]) base() *baseKeyedHeap[K, V] {
+	if h.check == nil {
+		h.init(minCheck[K])
+	}
+	return &h.baseKeyedHeap
+}
+
+func (h *KeyedMaxHeap[K, V]) base() *baseKeyedHeap[K, V] {
+	if h.check == nil {
+		h.init(maxCheck[K])
+	}
+	return &h.baseKeyedHeap
+}
+
+func (h *baseKeyedHeap[K, V]) up(idx int) {
+	key, ref := h.keys[idx], h.refs[idx]
+	for idx > 0 {
+		parent := (idx - 1) / h.factor
+		if !h.check(key, h.keys[parent]) {
+			break
+		}
+		h.keys[idx], h.refs[idx] = h.keys[parent], h.refs[parent]
+		idx = parent
+	}
+	h.keys[idx], h.refs[idx] = key, ref
+}
+
+func (h *baseKeyedHeap[K, V]) down(idx int) {
+	keys := h.keys
+	key, ref := keys[idx], h.refs[idx]
+	for {
+		first := idx*h.factor + 1
+		if first >= len(keys) {
+			break
+		}
+		child := first
+		for i := first + 1; i < min(first+h.factor, len(keys)); i++ {
+			if h.check(keys[i], keys[child]) {
+				child = i
+			}
+		}
+		if !h.check(keys[child], key) {
+			break
+		}
+		keys[idx], h.refs[idx] = keys[child], h.refs[child]
+		idx = child
+	}
+	keys[idx], h.refs[idx] = key, ref
+}
+
+// store puts value into free payload slot and returns its index
+func (h *baseKeyedHeap[K, V]) store(value V) int {
+	if n := len(h.free); n > 0 {
+		ref := h.free[n-1]
+		h.free = h.free[:n-1]
+		h.values[ref] = value
+		return ref
+	}
+	h.values = append(h.values, value)
+	return len(h.values) - 1
+}
+
+func (h *baseKeyedHeap[K, V]) push(key K, value V) {
+	if err := rejectNaN(h.rejectNaN, key); err != nil {
+		panic(err)
+	}
+	h.keys = append(h.keys, key)
+	h.refs = append(h.refs, h.store(value))
+	h.up(len(h.keys) - 1)
+}
+
+func (h *baseKeyedHeap[K, V]) pop() (K, V) {
+	if h.empty() {
+		panic(ErrEmpty)
+	}
+	key, ref := h.keys[0], h.refs[0]
+	value := h.values[ref]
+	var zero V
+	h.values[ref] = zero
+	h.free = append(h.free, ref)
+
+	last := len(h.keys) - 1
+	h.keys[0], h.refs[0] = h.keys[last], h.refs[last]
+	var zeroKey K
+	h.keys[last] = zeroKey
+	h.keys, h.refs = h.keys[:last], h.refs[:last]
+	if last > 0 {
+		h.down(0)
+	} else {
+		h.values, h.free = h.values[:0], h.free[:0]
+	}
+	return key, value
+}
+
+func (h *baseKeyedHeap[K, V]) pick() (K, V) {
+	if h.empty() {
+		panic(ErrEmpty)
+	}
+	return h.keys[0], h.values[h.refs[0]]
+}
+
+func (h *baseKeyedHeap[K, V]) tryPick() (K, V, bool) {
+	if h.empty() {
+		var key K
+		var value V
+		return key, value, false
+	}
+	key, value := h.pick()
+	return key, value, true
+}
+
+func (h *baseKeyedHeap[K, V]) tryPop() (K, V, bool) {
+	if h.empty() {
+		var key K
+		var value V
+		return key, value, false
+	}
+	key, value := h.pop()
+	return key, value, true
+}
+
+func (h *baseKeyedHeap[K, V]) heapify(keys []K, values []V) {
+	if len(keys) != len(values) {
+		panic(fmt.Sprintf("keys and values lengths differ: %d != %d", len(keys), len(values)))
+	}
+	if err := rejectNaN(h.rejectNaN, keys...); err != nil {
+		panic(err)
+	}
+	clear(h.values)
+	h.keys = append(h.keys[:0], keys...)
+	h.values = append(h.values[:0], values...)
+	h.refs = h.refs[:0]
+	h.free = h.free[:0]
+	for i := range keys {
+		h.refs = append(h.refs, i)
+	}
+	if len(keys) == 0 {
+		return
+	}
+	for i := (len(keys) - 1) / h.factor; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+func (h *baseKeyedHeap[K, V]) empty() bool {
+	return len(h.keys) == 0
+}
+
+func (h *baseKeyedHeap[K, V]) len() int {
+	return len(h.keys)
+}
+
+// Push adds value with key into heap
+func (h *KeyedMinHeap[K, V]) Push(key K, value V) {
+	h.base().push(key, value)
+}
+
+// Push adds value with key into heap
+func (h *KeyedMaxHeap[K, V]) Push(key K, value V) {
+	h.base().push(key, value)
+}
+
+// Pop returns and deletes value with min key
+func (h *KeyedMinHeap[K, V]) Pop() (K, V) {
+	return h.base().pop()
+}
+
+// Pop returns and deletes value with max key
+func (h *KeyedMaxHeap[K, V]) Pop() (K, V) {
+	return h.base().pop()
+}
+
+// TryPop returns and deletes value with min key. Returns false if heap is empty
+func (h *KeyedMinHeap[K, V]) TryPop() (K, V, bool) {
+	return h.base().tryPop()
+}
+
+// TryPop returns and deletes value with max key. Returns false if heap is empty
+func (h *KeyedMaxHeap[K, V]) TryPop() (K, V, bool) {
+	return h.base().tryPop()
+}
+
+// Pick returns value with min key
+func (h *KeyedMinHeap[K, V]) Pick() (K, V) {
+	return h.pick()
+}
+
+// Pick returns value with max key
+func (h *KeyedMaxHeap[K, V]) Pick() (K, V) {
+	return h.pick()
+}
+
+// TryPick returns value with min key. Returns false if heap is empty
+func (h *KeyedMinHeap[K, V]) TryPick() (K, V, bool) {
+	return h.tryPick()
+}
+
+// TryPick returns value with max key. Returns false if heap is empty
+func (h *KeyedMaxHeap[K, V]) TryPick() (K, V, bool) {
+	return h.tryPick()
+}
+
+// Empty either heap is blank
+func (h *KeyedMinHeap[K, V]) Empty() bool {
+	return h.empty()
+}
+
+// Empty either heap is blank
+func (h *KeyedMaxHeap[K, V]) Empty() bool {
+	return h.empty()
+}
+
+// Size returns heap size
+func (h *KeyedMinHeap[K, V]) Size() int {
+	return h.len()
+}
+
+// Size returns heap size
+func (h *KeyedMaxHeap[K, V]) Size() int {
+	return h.len()
+}
+
+// Heapify initializes heap with values and their keys. Panics if lengths differ
+func (h *KeyedMinHeap[K, V]) Heapify(keys []K, values []V) {
+	h.base().heapify(keys, values)
+}
+
+// Heapify initializes heap with values and their keys. Panics if lengths differ
+func (h *KeyedMaxHeap[K, V]) Heapify(keys []K, values []V) {
+	h.base().heapify(keys, values)
+}
@@ -0,0 +1,105 @@
+package ordered
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	name string
+	data [24]int64
+}
+
+func TestKeyedMinHeap(t *testing.T) {
+	for factor := 2; factor <= 4; factor++ {
+		h, _ := NewKeyedMinHeap[float64, payload](factor)
+		keys := rand.Perm(1000)
+		for _, key := range keys {
+			h.Push(float64(key), payload{name: fmt.Sprint(key)})
+		}
+		require.Equal(t, 1000, h.Size())
+
+		sort.Ints(keys)
+		for _, expected := range keys[:500] {
+			key, value := h.Pop()
+			require.Equal(t, float64(expected), key)
+			require.Equal(t, fmt.Sprint(expected), value.name)
+		}
+		for _, key := range keys[:500] {
+			h.Push(float64(key), payload{name: fmt.Sprint(key)})
+		}
+		require.Equal(t, 1000, len(h.values))
+
+		for _, expected := range keys {
+			key, value := h.Pop()
+			require.Equal(t, float64(expected), key)
+			require.Equal(t, fmt.Sprint(expected), value.name)
+		}
+		require.True(t, h.Empty())
+		require.Empty(t, h.values)
+	}
+}
+
+func TestKeyedMaxHeap(t *testing.T) {
+	h, _ := NewKeyedMaxHeap[int, string](3)
+	h.Heapify([]int{2, 5, 1, 4, 3}, []string{"b", "e", "a", "d", "c"})
+	key, value := h.Pick()
+	require.Equal(t, 5, key)
+	require.Equal(t, "e", value)
+
+	var values []string
+	for !h.Empty() {
+		_, value := h.Pop()
+		values = append(values, value)
+	}
+	require.Equal(t, []string{"e", "d", "c", "b", "a"}, values)
+	require.Panics(t, func() { h.Heapify([]int{1}, nil) })
+}
+
+func TestKeyedHeapZeroValue(t *testing.T) {
+	var h KeyedMinHeap[string, int]
+	_, _, ok := h.TryPop()
+	require.False(t, ok)
+	h.Push("b", 2)
+	h.Push("a", 1)
+	key, value, ok := h.TryPick()
+	require.True(t, ok)
+	require.Equal(t, "a", key)
+	require.Equal(t, 1, value)
+	require.Equal(t, 2, h.factor)
+
+	_, err := NewKeyedMinHeap[int, int](1)
+	require.Error(t, err)
+}
+
+func TestKeyedHeapRemoveIf(t *testing.T) {
+	h, _ := NewKeyedMinHeap[int, string](3)
+	for _, key := range rand.Perm(100) {
+		h.Push(key, fmt.Sprint(key))
+	}
+	require.Equal(t, 10, h.RemoveIf(func(key int, _ string) bool { return key%10 == 0 }))
+	require.Equal(t, 9, h.Retain(func(_ int, value string) bool { return len(value) == 2 }))
+	keys, values := h.Extract(func(key int, _ string) bool { return key > 95 })
+	sort.Ints(keys)
+	sort.Strings(values)
+	require.Equal(t, []int{96, 97, 98, 99}, keys)
+	require.Equal(t, []string{"96", "97", "98", "99"}, values)
+	require.NoError(t, h.Validate())
+	require.Equal(t, 77, h.Size())
+	// freed payload slots are reused
+	h.Push(1, "1")
+	require.Len(t, h.values, 100)
+	key, value := h.Pop()
+	require.Equal(t, 1, key)
+	require.Equal(t, "1", value)
+
+	maxH, _ := NewKeyedMaxHeap[int, string](2)
+	maxH.Heapify([]int{1, 2, 3}, []string{"a", "b", "c"})
+	require.Equal(t, 3, maxH.Retain(func(int, string) bool { return false }))
+	require.True(t, maxH.Empty())
+	require.Empty(t, maxH.values)
+}
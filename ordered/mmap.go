@@ -0,0 +1,453 @@
+//go:build linux || darwin
+
+package ordered
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/trezorg/heap"
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	mmapHeaderSize  = 64
+	mmapMinCapacity = 64
+	mmapMagic       = "HEAPMMAP"
+	mmapMinKind     = 0
+	mmapMaxKind     = 1
+)
+
+// ErrReadOnly is returned on modification of heap opened as read only
+var ErrReadOnly = errors.New("read only heap")
+
+// Fixed is constraint for fixed size types which can be stored in memory mapped file
+type Fixed interface {
+	constraints.Integer | constraints.Float
+}
+
+// mmapHeader is the header of memory mapped heap file
+type mmapHeader struct {
+	elemSize uint64
+	factor   uint64
+	kind     uint64
+	length   uint64
+}
+
+// baseMmapHeap heap structure with items backed by memory mapped file
+type baseMmapHeap[T Fixed] struct {
+	baseHeap[T]
+	file     *os.File
+	data     []byte
+	readOnly bool
+}
+
+// MmapMinHeap is heap stored in memory mapped file that returns element with min priority
+type MmapMinHeap[T Fixed] struct {
+	baseMmapHeap[T]
+}
+
+// MmapMaxHeap is heap stored in memory mapped file that returns element with max priority
+type MmapMaxHeap[T Fixed] struct {
+	baseMmapHeap[T]
+}
+
+func elemSize[T Fixed]() uint64 {
+	var item T
+	return uint64(unsafe.Sizeof(item))
+}
+
+func readMmapHeader(data []byte) (mmapHeader, error) {
+	if len(data) < mmapHeaderSize || !bytes.Equal(data[:len(mmapMagic)], []byte(mmapMagic)) {
+		return mmapHeader{}, errors.New("wrong heap file header")
+	}
+	return mmapHeader{
+		elemSize: binary.LittleEndian.Uint64(data[8:]),
+		factor:   binary.LittleEndian.Uint64(data[16:]),
+		kind:     binary.LittleEndian.Uint64(data[24:]),
+		length:   binary.LittleEndian.Uint64(data[32:]),
+	}, nil
+}
+
+func writeMmapHeader(data []byte, header mmapHeader) {
+	copy(data, mmapMagic)
+	binary.LittleEndian.PutUint64(data[8:], header.elemSize)
+	binary.LittleEndian.PutUint64(data[16:], header.factor)
+	binary.LittleEndian.PutUint64(data[24:], header.kind)
+	binary.LittleEndian.PutUint64(data[32:], header.length)
+}
+
+func openMmapHeap[T Fixed](
+	path string,
+	factor int,
+	kind uint64,
+	readOnly bool,
+	check func(item1 T, item2 T) bool,
+) (baseMmapHeap[T], error) {
+	flag, prot := os.O_RDWR|os.O_CREATE, syscall.PROT_READ|syscall.PROT_WRITE
+	if readOnly {
+		flag, prot = os.O_RDONLY, syscall.PROT_READ
+	}
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return baseMmapHeap[T]{}, fmt.Errorf("cannot open heap file: %w", err)
+	}
+	h, err := mapHeap(file, factor, kind, readOnly, prot, check)
+	if err != nil {
+		return baseMmapHeap[T]{}, errors.Join(err, file.Close())
+	}
+	return h, nil
+}
+
+func mapHeap[T Fixed](
+	file *os.File,
+	factor int,
+	kind uint64,
+	readOnly bool,
+	prot int,
+	check func(item1 T, item2 T) bool,
+) (baseMmapHeap[T], error) {
+	info, err := file.Stat()
+	if err != nil {
+		return baseMmapHeap[T]{}, fmt.Errorf("cannot stat heap file: %w", err)
+	}
+	size := info.Size()
+	header := mmapHeader{elemSize: elemSize[T](), factor: uint64(factor), kind: kind}
+	if size == 0 {
+		if readOnly {
+			return baseMmapHeap[T]{}, errors.New("wrong heap file header")
+		}
+		size = int64(mmapHeaderSize + header.elemSize*mmapMinCapacity)
+		if err := file.Truncate(size); err != nil {
+			return baseMmapHeap[T]{}, fmt.Errorf("cannot resize heap file: %w", err)
+		}
+	}
+
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size), prot, syscall.MAP_SHARED)
+	if err != nil {
+		return baseMmapHeap[T]{}, fmt.Errorf("cannot map heap file: %w", err)
+	}
+	if info.Size() == 0 {
+		writeMmapHeader(data, header)
+	}
+	stored, err := readMmapHeader(data)
+	if err == nil {
+		err = checkMmapHeader(stored, header, readOnly, uint64(size))
+	}
+	if err != nil {
+		return baseMmapHeap[T]{}, errors.Join(err, syscall.Munmap(data))
+	}
+
+	// heap file is shared by processes, so NaN items, which have no order, are not stored
+	baseHeap, err := newHeap(int(stored.factor), check, false, []Option{WithNaN(NaNReject)})
+	if err != nil {
+		return baseMmapHeap[T]{}, errors.Join(err, syscall.Munmap(data))
+	}
+	h := baseMmapHeap[T]{
+		baseHeap: baseHeap,
+		file:     file,
+		data:     data,
+		readOnly: readOnly,
+	}
+	h.items = h.mapped()[:stored.length]
+	return h, nil
+}
+
+func checkMmapHeader(stored, expected mmapHeader, readOnly bool, size uint64) error {
+	if stored.elemSize != expected.elemSize {
+		return fmt.Errorf("wrong heap file element size: %d, expected %d", stored.elemSize, expected.elemSize)
+	}
+	if stored.kind != expected.kind {
+		return errors.New("wrong heap file kind")
+	}
+	if !readOnly && stored.factor != expected.factor {
+		return fmt.Errorf("wrong heap file factor: %d, expected %d", stored.factor, expected.factor)
+	}
+	if stored.length > (size-mmapHeaderSize)/stored.elemSize {
+		return fmt.Errorf("wrong heap file length: %d", stored.length)
+	}
+	return nil
+}
+
+// OpenMmapMinHeap opens or creates min heap stored in file at path.
+// Factor of existing heap file should be equal to factor
+func OpenMmapMinHeap[T Fixed](path string, factor int) (*MmapMinHeap[T], error) {
+	baseHeap, err := openMmapHeap(path, factor, mmapMinKind, false, minCheck[T])
+	if err != nil {
+		return nil, err
+	}
+	return &MmapMinHeap[T]{baseHeap}, nil
+}
+
+// OpenMmapMaxHeap opens or creates max heap stored in file at path.
+// Factor of existing heap file should be equal to factor
+func OpenMmapMaxHeap[T Fixed](path string, factor int) (*MmapMaxHeap[T], error) {
+	baseHeap, err := openMmapHeap(path, factor, mmapMaxKind, false, maxCheck[T])
+	if err != nil {
+		return nil, err
+	}
+	return &MmapMaxHeap[T]{baseHeap}, nil
+}
+
+// OpenMmapMinHeapReadOnly opens existing min heap file at path as read only.
+// Reader reloads items length on every access, but file is not synchronized with the process which modifies it:
+// reader may see partially applied Push or Pop, so it may only read while the writer is quiescent,
+// for example after writer calls Sync and before it modifies heap again. Such coordination is up to caller
+func OpenMmapMinHeapReadOnly[T Fixed](path string) (*MmapMinHeap[T], error) {
+	baseHeap, err := openMmapHeap(path, 0, mmapMinKind, true, minCheck[T])
+	if err != nil {
+		return nil, err
+	}
+	return &MmapMinHeap[T]{baseHeap}, nil
+}
+
+// OpenMmapMaxHeapReadOnly opens existing max heap file at path as read only.
+// Reader reloads items length on every access, but file is not synchronized with the process which modifies it:
+// reader may see partially applied Push or Pop, so it may only read while the writer is quiescent,
+// for example after writer calls Sync and before it modifies heap again. Such coordination is up to caller
+func OpenMmapMaxHeapReadOnly[T Fixed](path string) (*MmapMaxHeap[T], error) {
+	baseHeap, err := openMmapHeap(path, 0, mmapMaxKind, true, maxCheck[T])
+	if err != nil {
+		return nil, err
+	}
+	return &MmapMaxHeap[T]{baseHeap}, nil
+}
+
+// mapped returns items slice over the whole mapped file
+func (h *baseMmapHeap[T]) mapped() []T {
+	capacity := (uint64(len(h.data)) - mmapHeaderSize) / elemSize[T]()
+	if capacity == 0 {
+		return nil
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(&h.data[mmapHeaderSize])), capacity)
+}
+
+// grow doubles heap file and maps it again
+func (h *baseMmapHeap[T]) grow() error {
+	length := len(h.items)
+	size := mmapHeaderSize + uint64(max(2*cap(h.items), mmapMinCapacity))*elemSize[T]()
+	if err := h.file.Truncate(int64(size)); err != nil {
+		return fmt.Errorf("cannot resize heap file: %w", err)
+	}
+	if err := syscall.Munmap(h.data); err != nil {
+		return fmt.Errorf("cannot unmap heap file: %w", err)
+	}
+	h.data, h.items = nil, nil
+	data, err := syscall.Mmap(int(h.file.Fd()), 0, int(size), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return fmt.Errorf("cannot map heap file: %w", err)
+	}
+	h.data = data
+	h.items = h.mapped()[:length]
+	return nil
+}
+
+// view returns heap items. Items length of read only heap is reloaded from file header,
+// so readers see changes made by the writer once it is quiescent
+func (h *baseMmapHeap[T]) view() *baseHeap[T] {
+	if h.readOnly && h.data != nil {
+		h.reload()
+	}
+	return &h.baseHeap
+}
+
+// reload reads items length from file header mapping file again when it has grown
+func (h *baseMmapHeap[T]) reload() {
+	length := binary.LittleEndian.Uint64(h.data[32:])
+	if length > uint64(cap(h.items)) {
+		if err := h.remap(); err != nil {
+			// keep the previous mapping, items beyond it are not visible until the next reload
+			length = uint64(cap(h.items))
+		}
+	}
+	h.items = h.mapped()[:min(length, uint64(len(h.mapped())))]
+}
+
+// remap maps heap file again with its current size
+func (h *baseMmapHeap[T]) remap() error {
+	info, err := h.file.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot stat heap file: %w", err)
+	}
+	if info.Size() <= int64(len(h.data)) {
+		return nil
+	}
+	data, err := syscall.Mmap(int(h.file.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return fmt.Errorf("cannot map heap file: %w", err)
+	}
+	if err := syscall.Munmap(h.data); err != nil {
+		return errors.Join(fmt.Errorf("cannot unmap heap file: %w", err), syscall.Munmap(data))
+	}
+	h.data = data
+	return nil
+}
+
+func (h *baseMmapHeap[T]) writeLength() {
+	binary.LittleEndian.PutUint64(h.data[32:], uint64(len(h.items)))
+}
+
+func (h *baseMmapHeap[T]) mmapPush(item T) error {
+	if h.readOnly {
+		return ErrReadOnly
+	}
+	if h.data == nil {
+		return os.ErrClosed
+	}
+	if err := h.accept(item); err != nil {
+		return err
+	}
+	if len(h.items) == cap(h.items) {
+		if err := h.grow(); err != nil {
+			return err
+		}
+	}
+	h.push(item)
+	h.writeLength()
+	return nil
+}
+
+func (h *baseMmapHeap[T]) mmapPop() (T, error) {
+	if h.readOnly {
+		var item T
+		return item, ErrReadOnly
+	}
+	if h.data == nil {
+		var item T
+		return item, os.ErrClosed
+	}
+	item := h.pop()
+	h.writeLength()
+	return item, nil
+}
+
+// Sync flushes heap changes into file
+func (h *baseMmapHeap[T]) Sync() error {
+	if h.readOnly || h.data == nil {
+		return nil
+	}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_MSYNC,
+		uintptr(unsafe.Pointer(&h.data[0])),
+		uintptr(len(h.data)),
+		syscall.MS_SYNC,
+	)
+	if errno != 0 {
+		return fmt.Errorf("cannot sync heap file: %w", errno)
+	}
+	return nil
+}
+
+// Close syncs heap, unmaps and closes heap file
+func (h *baseMmapHeap[T]) Close() error {
+	if h.data == nil {
+		return nil
+	}
+	err := h.Sync()
+	err = errors.Join(err, syscall.Munmap(h.data), h.file.Close())
+	h.data, h.items = nil, nil
+	return err
+}
+
+// Push adds item into heap, growing heap file when it is full. Returns ErrNaN for NaN item
+func (h *MmapMinHeap[T]) Push(item T) error {
+	return h.mmapPush(item)
+}
+
+// Push adds item into heap, growing heap file when it is full. Returns ErrNaN for NaN item
+func (h *MmapMaxHeap[T]) Push(item T) error {
+	return h.mmapPush(item)
+}
+
+// Pop returns and deletes min value
+func (h *MmapMinHeap[T]) Pop() (T, error) {
+	return h.mmapPop()
+}
+
+// Pop returns and deletes max value
+func (h *MmapMaxHeap[T]) Pop() (T, error) {
+	return h.mmapPop()
+}
+
+// Pick returns min value
+func (h *MmapMinHeap[T]) Pick() T {
+	return h.view().pick()
+}
+
+// Pick returns max value
+func (h *MmapMaxHeap[T]) Pick() T {
+	return h.view().pick()
+}
+
+// Empty either heap is blank
+func (h *MmapMinHeap[T]) Empty() bool {
+	return h.view().empty()
+}
+
+// Empty either heap is blank
+func (h *MmapMaxHeap[T]) Empty() bool {
+	return h.view().empty()
+}
+
+// Size returns heap size
+func (h *MmapMinHeap[T]) Size() int {
+	return h.view().len()
+}
+
+// Size returns heap size
+func (h *MmapMaxHeap[T]) Size() int {
+	return h.view().len()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *MmapMinHeap[T]) Validate() error {
+	return h.view().validate()
+}
+
+// Validate checks heap property of all items. Returns *heap.InvariantError
+// with level order indexes of the first item ordered before its parent
+func (h *MmapMaxHeap[T]) Validate() error {
+	return h.view().validate()
+}
+
+// Height returns number of heap levels
+func (h *MmapMinHeap[T]) Height() int {
+	return h.view().height()
+}
+
+// Height returns number of heap levels
+func (h *MmapMaxHeap[T]) Height() int {
+	return h.view().height()
+}
+
+// Factor returns heap factor
+func (h *MmapMinHeap[T]) Factor() int {
+	return h.factor
+}
+
+// Factor returns heap factor
+func (h *MmapMaxHeap[T]) Factor() int {
+	return h.factor
+}
+
+// Levels returns items grouped by heap levels starting from the min value
+func (h *MmapMinHeap[T]) Levels() [][]T {
+	return h.view().levelOrder()
+}
+
+// Levels returns items grouped by heap levels starting from the max value
+func (h *MmapMaxHeap[T]) Levels() [][]T {
+	return h.view().levelOrder()
+}
+
+// checks that memory mapped heaps implement heap.Inspector. Kept here as they are built for linux and darwin only
+func _[T Fixed]() {
+	var _ heap.Inspector[T] = (*MmapMinHeap[T])(nil)
+	var _ heap.Inspector[T] = (*MmapMaxHeap[T])(nil)
+}
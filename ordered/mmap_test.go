@@ -0,0 +1,131 @@
+//go:build linux || darwin
+
+package ordered
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMmapMinHeapReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heap")
+	h, err := OpenMmapMinHeap[int64](path, 3)
+	require.NoError(t, err)
+
+	items := rand.Perm(1000)
+	for _, item := range items {
+		require.NoError(t, h.Push(int64(item)))
+	}
+	require.Equal(t, 1000, h.Size())
+	require.NoError(t, h.Validate())
+	require.Equal(t, 3, h.Factor())
+	require.Equal(t, 7, h.Height())
+	require.NoError(t, h.Close())
+
+	h, err = OpenMmapMinHeap[int64](path, 3)
+	require.NoError(t, err)
+	defer h.Close()
+	require.Equal(t, 1000, h.Size())
+
+	sort.Ints(items)
+	for _, expected := range items[:500] {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		require.Equal(t, int64(expected), item)
+	}
+	require.NoError(t, h.Sync())
+
+	r, err := OpenMmapMinHeapReadOnly[int64](path)
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, 500, r.Size())
+	require.Equal(t, int64(items[500]), r.Pick())
+	require.True(t, errors.Is(r.Push(1), ErrReadOnly))
+	_, err = r.Pop()
+	require.True(t, errors.Is(err, ErrReadOnly))
+}
+
+func TestMmapMaxHeap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heap")
+	h, err := OpenMmapMaxHeap[float64](path, 2)
+	require.NoError(t, err)
+	defer h.Close()
+
+	require.True(t, h.Empty())
+	for _, item := range []float64{3, 1, 4, 1, 5, 9, 2, 6} {
+		require.NoError(t, h.Push(item))
+	}
+	require.True(t, errors.Is(h.Push(math.NaN()), ErrNaN))
+	require.Equal(t, 8, h.Size())
+	var res []float64
+	for !h.Empty() {
+		item, err := h.Pop()
+		require.NoError(t, err)
+		res = append(res, item)
+	}
+	require.Equal(t, []float64{9, 6, 5, 4, 3, 2, 1, 1}, res)
+
+	require.NoError(t, h.Push(1))
+	require.NoError(t, h.Close())
+	_, err = h.Pop()
+	require.True(t, errors.Is(err, os.ErrClosed))
+	require.True(t, errors.Is(h.Push(1), os.ErrClosed))
+}
+
+func TestMmapHeapWrongFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heap")
+	h, err := OpenMmapMinHeap[int32](path, 2)
+	require.NoError(t, err)
+	require.NoError(t, h.Close())
+
+	_, err = OpenMmapMinHeap[int32](path, 3)
+	require.Error(t, err)
+	_, err = OpenMmapMaxHeap[int32](path, 2)
+	require.Error(t, err)
+	_, err = OpenMmapMinHeap[int64](path, 2)
+	require.Error(t, err)
+	_, err = OpenMmapMinHeapReadOnly[int32](filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// length multiplied by element size overflows to a small value
+	binary.LittleEndian.PutUint64(data[32:], 1<<62+1)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	_, err = OpenMmapMinHeapReadOnly[int32](path)
+	require.Error(t, err)
+}
+
+func TestMmapReadOnlyReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heap")
+	h, err := OpenMmapMinHeap[int32](path, 2)
+	require.NoError(t, err)
+	defer h.Close()
+	require.NoError(t, h.Push(5))
+
+	r, err := OpenMmapMinHeapReadOnly[int32](path)
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, 1, r.Size())
+	require.Equal(t, int32(5), r.Pick())
+
+	for i := range 1000 {
+		require.NoError(t, h.Push(int32(i+10)))
+	}
+	require.Equal(t, 1001, r.Size())
+	require.Equal(t, int32(5), r.Pick())
+	require.NoError(t, r.Validate())
+
+	_, err = h.Pop()
+	require.NoError(t, err)
+	require.Equal(t, 1000, r.Size())
+	require.Equal(t, int32(10), r.Pick())
+}
@@ -0,0 +1,149 @@
+package ordered
+
+import "errors"
+
+// ErrNaN is returned or used as panic value on push of NaN item into heap with NaNReject policy
+var ErrNaN = errors.New("NaN item is rejected")
+
+// NaNPolicy defines how heap orders NaN items of floating point types
+type NaNPolicy int
+
+const (
+	// NaNUnordered compares NaN items with < and > operators.
+	// NaN breaks heap order, so pop order is undefined. It is default and the fastest policy
+	NaNUnordered NaNPolicy = iota
+	// NaNReject rejects NaN items. Push and Heapify panic with ErrNaN, TryPush and TryHeapify return it
+	NaNReject
+	// NaNFirst orders NaN items before all other items, so Pop returns them first.
+	// For min heap it is order of cmp.Compare
+	NaNFirst
+	// NaNLast orders NaN items after all other items, so Pop returns them last
+	NaNLast
+)
+
+func isNaN[T comparable](item T) bool {
+	return item != item
+}
+
+// nanCheck returns check ordering NaN items before other items if first is set, otherwise after them
+func nanCheck[T comparable](check func(item1 T, item2 T) bool, first bool) func(item1 T, item2 T) bool {
+	return func(item1 T, item2 T) bool {
+		nan1, nan2 := isNaN(item1), isNaN(item2)
+		if nan1 || nan2 {
+			if first {
+				return nan1 && !nan2
+			}
+			return nan2 && !nan1
+		}
+		return check(item1, item2)
+	}
+}
+
+// nanPolicy returns check ordering NaN items by policy and either NaN items are rejected.
+// Priority queue keeps the worst item on top, so policy is reversed for queue
+func nanPolicy[T comparable](check func(item1 T, item2 T) bool, policy NaNPolicy, queue bool) (func(item1 T, item2 T) bool, bool) {
+	switch policy {
+	case NaNFirst, NaNLast:
+		return nanCheck(check, (policy == NaNFirst) != queue), false
+	}
+	return check, policy == NaNReject
+}
+
+// rejectNaN returns ErrNaN if reject is set and any of items is NaN
+func rejectNaN[T comparable](reject bool, items ...T) error {
+	if !reject {
+		return nil
+	}
+	for _, item := range items {
+		if isNaN(item) {
+			return ErrNaN
+		}
+	}
+	return nil
+}
+
+// accept returns ErrNaN if heap rejects any of items
+func (h *baseHeap[T]) accept(items ...T) error {
+	return rejectNaN(h.rejectNaN, items...)
+}
+
+// mustAccept panics if heap rejects any of items
+func (h *baseHeap[T]) mustAccept(items ...T) {
+	if err := h.accept(items...); err != nil {
+		panic(err)
+	}
+}
+
+// TryPush adds item into heap. Returns ErrNaN if heap rejects item
+func (h *MinHeap[T]) TryPush(item T) error {
+	if err := h.base().accept(item); err != nil {
+		return err
+	}
+	h.push(item)
+	return nil
+}
+
+// TryPush adds item into heap. Returns ErrNaN if heap rejects item
+func (h *MaxHeap[T]) TryPush(item T) error {
+	if err := h.base().accept(item); err != nil {
+		return err
+	}
+	h.push(item)
+	return nil
+}
+
+// TryPush adds item into priority queue. Returns ErrNaN if priority queue rejects item
+func (h *MinPQ[T]) TryPush(item T) error {
+	if err := h.base().accept(item); err != nil {
+		return err
+	}
+	h.Push(item)
+	return nil
+}
+
+// TryPush adds item into priority queue. Returns ErrNaN if priority queue rejects item
+func (h *MaxPQ[T]) TryPush(item T) error {
+	if err := h.base().accept(item); err != nil {
+		return err
+	}
+	h.Push(item)
+	return nil
+}
+
+// TryHeapify initializes heap. Returns ErrNaN and keeps heap unchanged if heap rejects any of items
+func (h *MinHeap[T]) TryHeapify(items ...T) error {
+	if err := h.base().accept(items...); err != nil {
+		return err
+	}
+	h.heapify(items...)
+	return nil
+}
+
+// TryHeapify initializes heap. Returns ErrNaN and keeps heap unchanged if heap rejects any of items
+func (h *MaxHeap[T]) TryHeapify(items ...T) error {
+	if err := h.base().accept(items...); err != nil {
+		return err
+	}
+	h.heapify(items...)
+	return nil
+}
+
+// TryHeapify initializes priority queue.
+// Returns ErrNaN and keeps priority queue unchanged if priority queue rejects any of items
+func (h *MinPQ[T]) TryHeapify(items ...T) error {
+	if err := h.base().accept(items...); err != nil {
+		return err
+	}
+	h.Heapify(items...)
+	return nil
+}
+
+// TryHeapify initializes priority queue.
+// Returns ErrNaN and keeps priority queue unchanged if priority queue rejects any of items
+func (h *MaxPQ[T]) TryHeapify(items ...T) error {
+	if err := h.base().accept(items...); err != nil {
+		return err
+	}
+	h.Heapify(items...)
+	return nil
+}
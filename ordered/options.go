@@ -0,0 +1,117 @@
+package ordered
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedOption is returned by constructors for options which heap does not apply
+var ErrUnsupportedOption = errors.New("option is not supported")
+
+// Option configures heap or priority queue on construction
+type Option func(*options)
+
+type options struct {
+	capacity   int
+	bottomUp   bool
+	evict      any
+	merge      any
+	duplicates DuplicatePolicy
+	nan        NaNPolicy
+}
+
+// WithCapacity preallocates room for capacity items
+func WithCapacity(capacity int) Option {
+	return func(o *options) {
+		o.capacity = max(capacity, 0)
+	}
+}
+
+// WithBottomUp makes pop and heapify use bottom-up sift down, which moves the hole to a leaf
+// and sifts item up from there. It makes less comparisons for expensive comparisons
+func WithBottomUp() Option {
+	return func(o *options) {
+		o.bottomUp = true
+	}
+}
+
+// WithNaN sets policy for NaN items of floating point heaps. Block and keyed heaps support only this option
+func WithNaN(policy NaNPolicy) Option {
+	return func(o *options) {
+		o.nan = policy
+	}
+}
+
+// WithEvict sets callback receiving items evicted from bounded or weighted priority queue
+// on Push, PushEvict, Heapify and SetCapacity. Callback item type should match priority queue item type.
+// Heaps never evict items and return ErrUnsupportedOption for it
+func WithEvict[T any](evict func(item T)) Option {
+	return func(o *options) {
+		o.evict = evict
+	}
+}
+
+// WithDuplicates sets how unique heap or priority queue handles pushed item with the key of already kept item
+func WithDuplicates(policy DuplicatePolicy) Option {
+	return func(o *options) {
+		o.duplicates = policy
+	}
+}
+
+// WithMerge makes unique heap or priority queue replace kept item with result of merge
+// when item with the same key is pushed. Merge should return item with the same key,
+// otherwise push panics with ErrMergeKey
+func WithMerge[T any](merge func(kept, pushed T) T) Option {
+	return func(o *options) {
+		o.merge = merge
+		o.duplicates = MergeDuplicate
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// nanOnly returns ErrUnsupportedOption if options set anything but NaN policy
+func (o options) nanOnly() error {
+	if o.capacity != 0 || o.bottomUp || o.evict != nil || o.merge != nil || o.duplicates != IgnoreDuplicate {
+		return fmt.Errorf("%w: only WithNaN is applied", ErrUnsupportedOption)
+	}
+	return nil
+}
+
+// evictCallback returns eviction callback of options. Returns error if its item type is not T
+// or it is set for heap, which is not priority queue
+func evictCallback[T any](o options, queue bool) (func(item T), error) {
+	if o.evict == nil {
+		return nil, nil
+	}
+	if !queue {
+		return nil, fmt.Errorf("%w: WithEvict is applied to priority queues only", ErrUnsupportedOption)
+	}
+	evict, ok := o.evict.(func(item T))
+	if !ok {
+		return nil, fmt.Errorf("wrong type of eviction callback: %T, expected %T", o.evict, evict)
+	}
+	return evict, nil
+}
+
+// mergeFunc returns merge function of options. Returns error if its item type is not T
+// or merge policy is set without function
+func mergeFunc[T any](o options) (func(kept, pushed T) T, error) {
+	if o.merge == nil {
+		if o.duplicates == MergeDuplicate {
+			return nil, errors.New("merge function is required for merge policy")
+		}
+		return nil, nil
+	}
+	merge, ok := o.merge.(func(kept, pushed T) T)
+	if !ok {
+		return nil, fmt.Errorf("wrong type of merge function: %T, expected %T", o.merge, merge)
+	}
+	return merge, nil
+}
@@ -0,0 +1,82 @@
+package ordered
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSliceHeap(t *testing.T) {
+	items := rand.Perm(1000)
+	s := append([]int(nil), items[:500]...)
+	Init(s, 4)
+	require.True(t, IsHeap(s, 4))
+	for _, item := range items[500:] {
+		s = PushSlice(s, item, 4)
+	}
+	require.True(t, IsHeap(s, 4))
+
+	sort.Ints(items)
+	var item int
+	for _, expected := range items[:100] {
+		item, s = PopSlice(s, 4)
+		require.Equal(t, expected, item)
+	}
+	require.Len(t, s, 900)
+	require.True(t, IsHeap(s, 4))
+}
+
+func TestSliceMaxHeap(t *testing.T) {
+	items := rand.Perm(1000)
+	s := append([]int(nil), items[:500]...)
+	InitMax(s, 3)
+	require.True(t, IsHeapMax(s, 3))
+	require.False(t, IsHeap(s, 3))
+	for _, item := range items[500:] {
+		s = PushSliceMax(s, item, 3)
+	}
+	require.True(t, IsHeapMax(s, 3))
+
+	s[len(s)-1] = 2000
+	FixMax(s, len(s)-1, 3)
+	require.Equal(t, 2000, s[0])
+	item, s := RemoveAtMax(s, 0, 3)
+	require.Equal(t, 2000, item)
+	require.True(t, IsHeapMax(s, 3))
+
+	for expected := 999; expected >= 900; expected-- {
+		item, s = PopSliceMax(s, 3)
+		require.Equal(t, expected, item)
+	}
+	require.True(t, IsHeapMax(s, 3))
+}
+
+func TestSliceHeapFixRemoveAt(t *testing.T) {
+	s := []int{7, 3, 9, 1, 5, 8, 2}
+	Init(s, 2)
+
+	idx := len(s) - 1
+	s[idx] = -1
+	Fix(s, idx, 2)
+	require.Equal(t, -1, s[0])
+	s[0] = 10
+	Fix(s, 0, 2)
+	require.True(t, IsHeap(s, 2))
+
+	item, s := RemoveAt(s, 2, 2)
+	require.Len(t, s, 6)
+	require.True(t, IsHeap(s, 2))
+	require.NotContains(t, s, item)
+	item, s = RemoveAt(s, len(s)-1, 2)
+	require.Len(t, s, 5)
+	require.NotContains(t, s, item)
+
+	require.False(t, IsHeap([]int{2, 1}, 2))
+	require.True(t, IsHeap([]int{}, 2))
+	require.PanicsWithValue(t, ErrEmpty, func() { PopSlice([]int{}, 2) })
+	require.PanicsWithValue(t, ErrEmpty, func() { PopSliceMax([]int{}, 2) })
+	require.PanicsWithError(t, "wrong value for factor: 1. Cannot be less than 2", func() { Init([]int{2, 1}, 1) })
+	require.Panics(t, func() { PushSlice([]int{}, 1, 0) })
+}
@@ -0,0 +1,225 @@
+package ordered
+
+import (
+	"slices"
+
+	"github.com/trezorg/heap"
+	"golang.org/x/exp/constraints"
+)
+
+// newStorageHeap creates base heap keeping items in storage. Items already kept in storage are heapified.
+// Nil storage means items are kept in slice
+func newStorageHeap[T constraints.Ordered](
+	factor int,
+	storage heap.Storage[T],
+	check func(item1 T, item2 T) bool,
+	queue bool,
+	opts []Option,
+) (baseHeap[T], error) {
+	h, err := newHeap(factor, check, queue, opts)
+	if err != nil || storage == nil {
+		return h, err
+	}
+	h.items, h.storage = nil, storage
+	if h.rejectNaN {
+		if err := h.accept(h.snapshot()...); err != nil {
+			return baseHeap[T]{}, err
+		}
+	}
+	h.rebuild()
+	return h, nil
+}
+
+// NewStorageMinHeap heap constructor. Heap keeps items in storage, items already kept there are heapified.
+// Nil storage means slice as in NewMinHeap
+func NewStorageMinHeap[T constraints.Ordered](factor int, storage heap.Storage[T], opts ...Option) (MinHeap[T], error) {
+	baseHeap, err := newStorageHeap(factor, storage, minCheck[T], false, opts)
+	if err != nil {
+		return MinHeap[T]{}, err
+	}
+	return MinHeap[T]{baseHeap}, nil
+}
+
+// NewStorageMaxHeap heap constructor. Heap keeps items in storage, items already kept there are heapified.
+// Nil storage means slice as in NewMaxHeap
+func NewStorageMaxHeap[T constraints.Ordered](factor int, storage heap.Storage[T], opts ...Option) (MaxHeap[T], error) {
+	baseHeap, err := newStorageHeap(factor, storage, maxCheck[T], false, opts)
+	if err != nil {
+		return MaxHeap[T]{}, err
+	}
+	return MaxHeap[T]{baseHeap}, nil
+}
+
+// NewStorageMaxPQ creates maximum priority Queue with heap factor 2 keeping items in storage.
+// Items already kept in storage are heapified, the ones exceeding size are evicted.
+// Nil storage means slice as in NewMaxPQ. Size should be positive or Unbounded
+func NewStorageMaxPQ[T constraints.Ordered](size int, storage heap.Storage[T], opts ...Option) (MaxPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return MaxPQ[T]{}, err
+	}
+	baseHeap, err := newStorageHeap(2, storage, minCheck[T], true, opts)
+	if err != nil {
+		return MaxPQ[T]{}, err
+	}
+	baseHeap.shrinkTo(size)
+	return MaxPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+// NewStorageMinPQ creates minimum priority Queue with heap factor 2 keeping items in storage.
+// Items already kept in storage are heapified, the ones exceeding size are evicted.
+// Nil storage means slice as in NewMinPQ. Size should be positive or Unbounded
+func NewStorageMinPQ[T constraints.Ordered](size int, storage heap.Storage[T], opts ...Option) (MinPQ[T], error) {
+	if err := checkSize(size); err != nil {
+		return MinPQ[T]{}, err
+	}
+	baseHeap, err := newStorageHeap(2, storage, maxCheck[T], true, opts)
+	if err != nil {
+		return MinPQ[T]{}, err
+	}
+	baseHeap.shrinkTo(size)
+	return MinPQ[T]{baseHeap: baseHeap, size: size}, nil
+}
+
+// at returns item at idx
+func (h *baseHeap[T]) at(idx int) T {
+	if h.storage != nil {
+		return h.storage.Get(idx)
+	}
+	return h.items[idx]
+}
+
+// put replaces item at idx without logging
+func (h *baseHeap[T]) put(idx int, item T) {
+	if h.storage != nil {
+		h.storage.Set(idx, item)
+		return
+	}
+	h.items[idx] = item
+}
+
+// extend adds items after the last one without logging
+func (h *baseHeap[T]) extend(items []T) {
+	if h.storage == nil {
+		h.items = append(h.items, items...)
+		return
+	}
+	for _, item := range items {
+		h.storage.Append(item)
+	}
+}
+
+// drop deletes items starting from n without logging. Deleted items are zeroed to be garbage collected
+func (h *baseHeap[T]) drop(n int) {
+	if h.storage != nil {
+		h.storage.Truncate(n)
+		return
+	}
+	clear(h.items[n:])
+	h.items = h.items[:n]
+}
+
+// load replaces items without logging. Slice is kept as is, items are copied into storage
+func (h *baseHeap[T]) load(items []T) {
+	if h.storage == nil {
+		h.items = items
+		return
+	}
+	h.storage.Truncate(0)
+	h.extend(items)
+}
+
+// snapshot returns copy of items
+func (h *baseHeap[T]) snapshot() []T {
+	if h.storage == nil {
+		return slices.Clone(h.items)
+	}
+	res := make([]T, h.storage.Len())
+	for i := range res {
+		res[i] = h.storage.Get(i)
+	}
+	return res
+}
+
+// popStorage returns and deletes the top item of storage
+func (h *baseHeap[T]) popStorage() T {
+	last := h.storage.Len() - 1
+	item := h.storage.Get(0)
+	h.set(0, h.storage.Get(last))
+	h.truncate(last)
+	h.down(0)
+	return item
+}
+
+// siftUpStorage moves item at idx of storage up and returns its new index
+func (h *baseHeap[T]) siftUpStorage(idx int) int {
+	s := h.storage
+	item := s.Get(idx)
+	for idx > 0 {
+		parent := h.parent(idx)
+		parentItem := s.Get(parent)
+		if !h.check(item, parentItem) {
+			break
+		}
+		s.Set(idx, parentItem)
+		idx = parent
+	}
+	s.Set(idx, item)
+	return idx
+}
+
+// siftDownStorage moves item at idx of storage down choosing the best of factor children
+// and returns its new index. Bottom up sifting moves hole to a leaf and then sifts item up
+func (h *baseHeap[T]) siftDownStorage(idx int) int {
+	s := h.storage
+	n := s.Len()
+	item := s.Get(idx)
+	start := idx
+	for {
+		first, last := h.children(idx)
+		if first >= n {
+			break
+		}
+		child, childItem := first, s.Get(first)
+		for i := first + 1; i < min(last, n); i++ {
+			if other := s.Get(i); h.check(other, childItem) {
+				child, childItem = i, other
+			}
+		}
+		if !h.bottomUp && !h.check(childItem, item) {
+			break
+		}
+		s.Set(idx, childItem)
+		idx = child
+	}
+	for h.bottomUp && idx > start {
+		parent := h.parent(idx)
+		parentItem := s.Get(parent)
+		if !h.check(item, parentItem) {
+			break
+		}
+		s.Set(idx, parentItem)
+		idx = parent
+	}
+	s.Set(idx, item)
+	return idx
+}
+
+// Storage returns storage keeping heap items. Returns nil if items are kept in slice
+func (h *MinHeap[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping heap items. Returns nil if items are kept in slice
+func (h *MaxHeap[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping priority queue items. Returns nil if items are kept in slice
+func (h *MinPQ[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
+
+// Storage returns storage keeping priority queue items. Returns nil if items are kept in slice
+func (h *MaxPQ[T]) Storage() heap.Storage[T] {
+	return h.storage
+}
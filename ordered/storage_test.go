@@ -0,0 +1,116 @@
+package ordered
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trezorg/heap"
+)
+
+// countingStorage counts writes into slice storage
+type countingStorage[T any] struct {
+	heap.SliceStorage[T]
+	sets int
+}
+
+func (s *countingStorage[T]) Set(i int, item T) {
+	s.sets++
+	s.SliceStorage.Set(i, item)
+}
+
+func TestStorageHeap(t *testing.T) {
+	chunked, _ := heap.NewChunkedStorage[int](8)
+	for _, storage := range []heap.Storage[int]{&heap.SliceStorage[int]{}, chunked, &countingStorage[int]{}} {
+		for _, factor := range []int{2, 3, 4} {
+			for _, opts := range [][]Option{nil, {WithBottomUp()}} {
+				items := rand.Perm(1000)
+				h, err := NewStorageMinHeap(factor, storage, opts...)
+				require.NoError(t, err)
+				for _, item := range items[:500] {
+					h.Push(item)
+				}
+				require.NoError(t, h.Validate())
+				maxH, _ := NewStorageMaxHeap(factor, h.Storage(), opts...)
+				require.NoError(t, maxH.Validate())
+				maxH.PushMany(items[500:]...)
+				require.Equal(t, 1000, maxH.Size())
+				require.Equal(t, 999, maxH.Pick())
+				require.Equal(t, 1000, storage.Len())
+
+				clone := maxH.Clone()
+				require.Nil(t, clone.Storage())
+				require.Equal(t, 500, maxH.RemoveIf(func(item int) bool { return item%2 == 0 }))
+				require.NoError(t, maxH.Validate())
+
+				sort.Sort(sort.Reverse(sort.IntSlice(items)))
+				require.Equal(t, items, clone.Slice())
+				var odd []int
+				for _, item := range items {
+					if item%2 != 0 {
+						odd = append(odd, item)
+					}
+				}
+				require.Equal(t, odd, maxH.Slice())
+				require.True(t, h.Empty())
+			}
+		}
+	}
+
+	h, err := NewStorageMinHeap[int](2, nil)
+	require.NoError(t, err)
+	require.Nil(t, h.Storage())
+	h.Heapify(3, 1, 2)
+	require.Equal(t, []int{1, 2, 3}, h.Slice())
+
+	counting := &countingStorage[int]{}
+	h, _ = NewStorageMinHeap[int](2, counting)
+	h.Push(1)
+	require.Greater(t, counting.sets, 0)
+}
+
+func TestStoragePQ(t *testing.T) {
+	storage := &heap.SliceStorage[int]{}
+	for _, item := range rand.Perm(100) {
+		storage.Append(item)
+	}
+	var evicted []int
+	pq, err := NewStorageMaxPQ(10, storage, WithEvict(func(item int) { evicted = append(evicted, item) }))
+	require.NoError(t, err)
+	require.Equal(t, 10, storage.Len())
+	require.Len(t, evicted, 90)
+	for _, item := range rand.Perm(100) {
+		pq.Push(item + 100)
+	}
+	require.NoError(t, pq.Validate())
+	require.Equal(t, []int{199, 198, 197, 196, 195, 194, 193, 192, 191, 190}, pq.OrderedSlice())
+
+	minPQ, err := NewStorageMinPQ[int](3, &heap.SliceStorage[int]{})
+	require.NoError(t, err)
+	minPQ.Heapify(5, 4, 3, 2, 1)
+	require.Equal(t, []int{1, 2, 3}, minPQ.OrderedSlice())
+
+	_, err = NewStorageMinPQ[int](0, storage)
+	require.True(t, errors.Is(err, ErrInvalidSize))
+}
+
+func TestStorageCheckpoint(t *testing.T) {
+	chunked, _ := heap.NewChunkedStorage[int](4)
+	h, _ := NewStorageMinHeap[int](3, chunked)
+	h.Heapify(rand.Perm(50)...)
+	before := h.Levels()
+	cp := h.Checkpoint()
+	for _, item := range rand.Perm(20) {
+		h.Push(item)
+		h.Pop()
+	}
+	h.PushMany(rand.Perm(100)...)
+	h.RemoveIf(func(item int) bool { return item%3 == 0 })
+	h.Clear()
+	h.Push(7)
+	require.NoError(t, h.Rollback(cp))
+	require.Equal(t, before, h.Levels())
+	require.Equal(t, 50, chunked.Len())
+}
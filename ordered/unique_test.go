@@ -0,0 +1,150 @@
+package ordered
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func lastDigit(item int) int {
+	return item % 10
+}
+
+func TestUniqueHeapPolicies(t *testing.T) {
+	h, err := NewUniqueMinHeap(2, lastDigit)
+	require.NoError(t, err)
+	for _, item := range []int{15, 5, 25, 7} {
+		h.Push(item)
+	}
+	require.Equal(t, []int{7, 15}, h.Slice())
+
+	h, _ = NewUniqueMinHeap(3, lastDigit, WithDuplicates(ReplaceBetter))
+	items := []int{15, 5, 25, 7, 17}
+	h.Heapify(items...)
+	require.Equal(t, []int{15, 5, 25, 7, 17}, items)
+	require.Equal(t, []int{5, 7}, h.Slice())
+
+	maxH, _ := NewUniqueMaxHeap(2, lastDigit, WithDuplicates(ReplaceDuplicate))
+	for _, item := range []int{15, 5, 25, 7} {
+		maxH.Push(item)
+	}
+	require.Equal(t, []int{25, 7}, maxH.Slice())
+
+	maxH, _ = NewUniqueMaxHeap(2, lastDigit, WithMerge(func(kept, pushed int) int { return kept + pushed - pushed%10 }))
+	for _, item := range []int{11, 2, 13, 21, 33} {
+		maxH.Push(item)
+	}
+	require.Equal(t, []int{43, 31, 2}, maxH.Slice())
+
+	maxH, _ = NewUniqueMaxHeap(2, lastDigit, WithMerge(func(kept, pushed int) int { return kept + pushed }))
+	maxH.Push(11)
+	require.PanicsWithValue(t, ErrMergeKey, func() { maxH.Push(21) })
+	require.PanicsWithValue(t, ErrMergeKey, func() { maxH.Heapify(12, 22) })
+
+	_, err = NewUniqueMinHeap(2, lastDigit, WithDuplicates(MergeDuplicate))
+	require.Error(t, err)
+	_, err = NewUniqueMinHeap(2, lastDigit, WithMerge(func(kept, pushed string) string { return kept }))
+	require.Error(t, err)
+	_, err = NewUniqueMinHeap[int, int](2, nil)
+	require.Error(t, err)
+}
+
+func TestUniqueHeapIndex(t *testing.T) {
+	for _, bottomUp := range []bool{false, true} {
+		opts := []Option{WithDuplicates(ReplaceBetter)}
+		if bottomUp {
+			opts = append(opts, WithBottomUp())
+		}
+		h, _ := NewUniqueMinHeap(3, func(item int) int { return item % 100 }, opts...)
+		best := map[int]int{}
+		for i := 0; i < 5000; i++ {
+			item := rand.Intn(100000)
+			h.Push(item)
+			if kept, ok := best[item%100]; !ok || item < kept {
+				best[item%100] = item
+			}
+			if i%7 == 0 {
+				key := rand.Intn(100)
+				expected, kept := best[key]
+				item, ok := h.Remove(key)
+				require.Equal(t, kept, ok)
+				require.Equal(t, expected, item)
+				require.False(t, h.Contains(key))
+				delete(best, key)
+			}
+		}
+		for key, item := range h.index {
+			require.Equal(t, key, h.keys[item])
+			require.Equal(t, key, h.items[item]%100)
+			kept, ok := h.Get(key)
+			require.True(t, ok)
+			require.Equal(t, best[key], kept)
+		}
+		require.NoError(t, h.Validate())
+		expected := make([]int, 0, len(best))
+		for _, item := range best {
+			expected = append(expected, item)
+		}
+		sort.Ints(expected)
+		require.Equal(t, expected, h.Slice())
+		require.Empty(t, h.index)
+	}
+}
+
+func TestUniquePQ(t *testing.T) {
+	var evicted []int
+	pq, err := NewUniqueMinPQ(3, lastDigit, WithDuplicates(ReplaceBetter))
+	require.NoError(t, err)
+	pq.OnEvict(func(item int) { evicted = append(evicted, item) })
+	pq.Heapify(14, 5, 25, 3, 1)
+	require.Equal(t, []int{14}, evicted)
+	require.False(t, pq.Contains(4))
+
+	pq.Push(11)
+	require.Equal(t, 3, pq.Size())
+	item, ok := pq.PushEvict(2)
+	require.True(t, ok)
+	require.Equal(t, 5, item)
+	require.False(t, pq.Contains(5))
+	pq.Push(5)
+	require.Equal(t, []int{14, 5, 5}, evicted)
+
+	shrunk, err := pq.SetCapacity(2)
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, shrunk)
+	require.Equal(t, []int{14, 5, 5, 3}, evicted)
+	require.Equal(t, []int{1, 2}, pq.OrderedSlice())
+
+	maxPQ, _ := NewUniqueMaxPQ(2, lastDigit)
+	maxPQ.Heapify(1, 11, 2, 3)
+	require.Equal(t, []int{3, 2}, maxPQ.OrderedSlice())
+
+	_, err = NewUniqueMaxPQ(0, lastDigit)
+	require.True(t, errors.Is(err, ErrInvalidSize))
+}
+
+func TestUniqueRemoveIf(t *testing.T) {
+	h, _ := NewUniqueMinHeap(2, func(item int) int { return item % 100 }, WithDuplicates(ReplaceBetter))
+	h.Heapify(rand.Perm(300)...)
+	require.Equal(t, 10, h.RemoveIf(func(item int) bool { return item%10 == 0 }))
+	require.False(t, h.Contains(10))
+	require.Equal(t, 40, h.Retain(func(item int) bool { return item%2 == 1 }))
+	require.False(t, h.Contains(2))
+	extracted := h.Extract(func(item int) bool { return item < 5 })
+	sort.Ints(extracted)
+	require.Equal(t, []int{1, 3}, extracted)
+	require.NoError(t, h.Validate())
+	require.Equal(t, 48, h.Size())
+	h.Push(110)
+	require.True(t, h.Contains(10))
+
+	pq, _ := NewUniqueMaxPQ(3, lastDigit)
+	pq.Heapify(1, 2, 3, 4, 5)
+	require.Equal(t, 1, pq.RemoveIf(func(item int) bool { return item == 4 }))
+	pq.Push(14)
+	require.NoError(t, pq.Validate())
+	require.Equal(t, []int{14, 5, 3}, pq.OrderedSlice())
+}
@@ -0,0 +1,92 @@
+package ordered
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedPQ(t *testing.T) {
+	var evicted []string
+	pq, err := NewWeightedMinPQ(10, func(item string) int { return len(item) })
+	require.NoError(t, err)
+	pq.OnEvict(func(item string) { evicted = append(evicted, item) })
+	require.Equal(t, 10, pq.Limit())
+
+	pq.Push("d")
+	pq.Push("cccc")
+	pq.Push("bbbb")
+	require.Equal(t, 9, pq.Weight())
+	// replaces the top item
+	pq.Push("aa")
+	require.Equal(t, []string{"d"}, evicted)
+	require.Equal(t, 10, pq.Weight())
+	// evicts two items
+	pq.Push("aaaaaa")
+	require.Equal(t, []string{"d", "cccc", "bbbb"}, evicted)
+	require.Equal(t, 8, pq.Weight())
+	// the worst item and the item heavier than limit are evicted themselves
+	pq.Push("zzz")
+	pq.Push(strings.Repeat("a", 11))
+	require.Equal(t, []string{"d", "cccc", "bbbb", "zzz", strings.Repeat("a", 11)}, evicted)
+
+	require.Equal(t, []string{"aa", "aaaaaa"}, pq.OrderedSlice())
+	require.Equal(t, 0, pq.Weight())
+}
+
+func TestWeightedPQHeapify(t *testing.T) {
+	items := rand.Perm(100)
+	pq, _ := NewWeightedMaxPQ(100, func(item int) int { return 10 })
+	pq.Heapify(items...)
+	require.Equal(t, 10, pq.Size())
+	require.Equal(t, 100, pq.Weight())
+	for item := 100; item < 105; item++ {
+		pq.Push(item)
+	}
+	require.Equal(t, 10, pq.Size())
+	require.Equal(t, 95, pq.Pop())
+	require.Equal(t, 90, pq.Weight())
+	require.Equal(t, []int{104, 103, 102, 101, 100, 99, 98, 97, 96}, pq.OrderedSlice())
+
+	var zero WeightedMinPQ[int]
+	zero.Heapify(items...)
+	sort.Ints(items)
+	require.Equal(t, items, zero.OrderedSlice())
+	zero.Heapify(items...)
+	require.Equal(t, 100, zero.Size())
+	require.Equal(t, Unbounded, zero.Limit())
+
+	_, err := NewWeightedMinPQ(0, func(item int) int { return 1 })
+	require.True(t, errors.Is(err, ErrInvalidSize))
+	_, err = NewWeightedMaxPQ[int](10, nil)
+	require.Error(t, err)
+}
+
+func TestWeightedPQNegativeWeight(t *testing.T) {
+	pq, _ := NewWeightedMaxPQ(10, func(item int) int { return item })
+	pq.Heapify(1, 2)
+	require.True(t, errors.Is(pq.TryPush(-1), ErrInvalidWeight))
+	require.True(t, errors.Is(pq.TryHeapify(3, -2), ErrInvalidWeight))
+	require.Panics(t, func() { pq.Push(-3) })
+	require.Panics(t, func() { pq.Heapify(-4) })
+	require.NoError(t, pq.TryPush(3))
+	require.Equal(t, 6, pq.Weight())
+	require.Equal(t, []int{3, 2, 1}, pq.OrderedSlice())
+}
+
+func TestWeightedPQRemoveIf(t *testing.T) {
+	pq, _ := NewWeightedMinPQ(20, func(item int) int { return item })
+	pq.Heapify(1, 2, 3, 4, 5)
+	require.Equal(t, 2, pq.RemoveIf(func(item int) bool { return item%2 == 0 }))
+	require.Equal(t, 9, pq.Weight())
+	require.Equal(t, []int{5}, pq.Extract(func(item int) bool { return item == 5 }))
+	require.Equal(t, 1, pq.Retain(func(item int) bool { return item == 3 }))
+	require.Equal(t, 3, pq.Weight())
+	pq.Push(17)
+	require.Equal(t, 20, pq.Weight())
+	require.NoError(t, pq.Validate())
+}
@@ -0,0 +1,150 @@
+package heap
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+const defaultChunkSize = 1024
+
+// ErrInvalidChunkSize is returned by NewChunkedStorage for chunk size which is not power of two
+var ErrInvalidChunkSize = errors.New("wrong value for chunk size")
+
+// Storage keeps heap items addressed by index
+type Storage[T any] interface {
+	// Len returns number of items
+	Len() int
+	// Get returns item at index i
+	Get(i int) T
+	// Set replaces item at index i
+	Set(i int, item T)
+	// Append adds item after the last one
+	Append(item T)
+	// Truncate deletes items starting from index n
+	Truncate(n int)
+	// Swap swaps items at indexes i and j
+	Swap(i, j int)
+}
+
+// SliceStorage is Storage backed by a single slice. Zero value is empty storage
+type SliceStorage[T any] struct {
+	items []T
+}
+
+// NewSliceStorage creates slice storage with room for capacity items
+func NewSliceStorage[T any](capacity int) *SliceStorage[T] {
+	return &SliceStorage[T]{items: make([]T, 0, max(capacity, 0))}
+}
+
+// Len returns number of items
+func (s *SliceStorage[T]) Len() int {
+	return len(s.items)
+}
+
+// Get returns item at index i
+func (s *SliceStorage[T]) Get(i int) T {
+	return s.items[i]
+}
+
+// Set replaces item at index i
+func (s *SliceStorage[T]) Set(i int, item T) {
+	s.items[i] = item
+}
+
+// Append adds item after the last one
+func (s *SliceStorage[T]) Append(item T) {
+	s.items = append(s.items, item)
+}
+
+// Truncate deletes items starting from index n
+func (s *SliceStorage[T]) Truncate(n int) {
+	clear(s.items[n:])
+	s.items = s.items[:n]
+}
+
+// Swap swaps items at indexes i and j
+func (s *SliceStorage[T]) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+}
+
+// ChunkedStorage is Storage backed by fixed size chunks. Growth allocates a new chunk
+// and never copies items, so there are no large reallocations.
+// Zero value is empty storage with chunks of 1024 items
+type ChunkedStorage[T any] struct {
+	chunks    [][]T
+	size      int
+	chunkSize int
+	shift     int
+}
+
+// NewChunkedStorage creates chunked storage with chunks of chunkSize items.
+// Chunk size should be power of two
+func NewChunkedStorage[T any](chunkSize int) (*ChunkedStorage[T], error) {
+	if chunkSize < 1 || bits.OnesCount(uint(chunkSize)) != 1 {
+		return nil, fmt.Errorf("%w: %d. Should be power of two", ErrInvalidChunkSize, chunkSize)
+	}
+	return &ChunkedStorage[T]{chunkSize: chunkSize, shift: bits.TrailingZeros(uint(chunkSize))}, nil
+}
+
+// position returns chunk and index in chunk of item at index i
+func (s *ChunkedStorage[T]) position(i int) (int, int) {
+	if i < 0 || i >= s.size {
+		panic(fmt.Sprintf("index out of range [%d] with length %d", i, s.size))
+	}
+	return i >> s.shift, i & (s.chunkSize - 1)
+}
+
+// Len returns number of items
+func (s *ChunkedStorage[T]) Len() int {
+	return s.size
+}
+
+// Get returns item at index i
+func (s *ChunkedStorage[T]) Get(i int) T {
+	chunk, idx := s.position(i)
+	return s.chunks[chunk][idx]
+}
+
+// Set replaces item at index i
+func (s *ChunkedStorage[T]) Set(i int, item T) {
+	chunk, idx := s.position(i)
+	s.chunks[chunk][idx] = item
+}
+
+// Append adds item after the last one
+func (s *ChunkedStorage[T]) Append(item T) {
+	if s.chunkSize == 0 {
+		s.chunkSize, s.shift = defaultChunkSize, bits.TrailingZeros(defaultChunkSize)
+	}
+	if s.size == len(s.chunks)*s.chunkSize {
+		s.chunks = append(s.chunks, make([]T, s.chunkSize))
+	}
+	s.size++
+	s.Set(s.size-1, item)
+}
+
+// Truncate deletes items starting from index n. Chunks which are not used anymore are released
+func (s *ChunkedStorage[T]) Truncate(n int) {
+	if n > s.size || n < 0 {
+		panic(fmt.Sprintf("truncate length %d out of range with length %d", n, s.size))
+	}
+	if n == s.size {
+		return
+	}
+	for i := n; i < s.size; i++ {
+		var zero T
+		s.Set(i, zero)
+	}
+	s.size = n
+	used := (n + s.chunkSize - 1) >> s.shift
+	clear(s.chunks[used:])
+	s.chunks = s.chunks[:used]
+}
+
+// Swap swaps items at indexes i and j
+func (s *ChunkedStorage[T]) Swap(i, j int) {
+	ci, i := s.position(i)
+	cj, j := s.position(j)
+	s.chunks[ci][i], s.chunks[cj][j] = s.chunks[cj][j], s.chunks[ci][i]
+}
@@ -0,0 +1,59 @@
+package heap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testStorage(t *testing.T, s Storage[int]) {
+	for i := 0; i < 100; i++ {
+		s.Append(i)
+	}
+	require.Equal(t, 100, s.Len())
+	require.Equal(t, 42, s.Get(42))
+	s.Set(42, -42)
+	require.Equal(t, -42, s.Get(42))
+	s.Swap(0, 99)
+	require.Equal(t, 99, s.Get(0))
+	require.Equal(t, 0, s.Get(99))
+	s.Truncate(10)
+	require.Equal(t, 10, s.Len())
+	require.Panics(t, func() { s.Get(10) })
+	s.Append(7)
+	require.Equal(t, 7, s.Get(10))
+	s.Truncate(0)
+	require.Equal(t, 0, s.Len())
+}
+
+func TestSliceStorage(t *testing.T) {
+	testStorage(t, &SliceStorage[int]{})
+	testStorage(t, NewSliceStorage[int](10))
+}
+
+func TestChunkedStorage(t *testing.T) {
+	testStorage(t, &ChunkedStorage[int]{})
+	for _, chunkSize := range []int{1, 4, 16} {
+		s, err := NewChunkedStorage[int](chunkSize)
+		require.NoError(t, err)
+		testStorage(t, s)
+	}
+
+	s, _ := NewChunkedStorage[int](4)
+	for i := 0; i < 10; i++ {
+		s.Append(i)
+	}
+	first := &s.chunks[0][0]
+	for i := 10; i < 1000; i++ {
+		s.Append(i)
+	}
+	require.Same(t, first, &s.chunks[0][0])
+	s.Truncate(5)
+	require.Len(t, s.chunks, 2)
+
+	_, err := NewChunkedStorage[int](3)
+	require.True(t, errors.Is(err, ErrInvalidChunkSize))
+	_, err = NewChunkedStorage[int](0)
+	require.True(t, errors.Is(err, ErrInvalidChunkSize))
+}